@@ -1,24 +1,108 @@
 package api
 
 import (
+	"net/http"
+
 	"github.com/Fs02/go-todo-backend/api/handler"
+	"github.com/Fs02/go-todo-backend/api/middleware"
+	"github.com/Fs02/go-todo-backend/diagnostics"
+	"github.com/Fs02/go-todo-backend/events"
+	"github.com/Fs02/go-todo-backend/maintenance"
 	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/search"
 	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/Fs02/go-todo-backend/views"
 	"github.com/go-chi/chi"
 	chimid "github.com/go-chi/chi/middleware"
 	"github.com/go-rel/rel"
 	"github.com/goware/cors"
 )
 
+// DefaultMaxBodySize used when config doesn't specify max body size.
+const DefaultMaxBodySize = 1 << 20
+
+// corsOptions allows any origin like cors.AllowAll, and exposes the response headers clients read,
+// browsers hide any other header from cross origin requests.
+var corsOptions = cors.Options{
+	AllowedOrigins: []string{"*"},
+	AllowedMethods: []string{
+		http.MethodHead,
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+	},
+	AllowedHeaders: []string{"*"},
+	ExposedHeaders: []string{
+		"Location",
+		"Retry-After",
+		handler.PaginationLimitHeader,
+		handler.PaginationOffsetHeader,
+		middleware.ServerTimingHeader,
+	},
+}
+
+// Config for api.
+type Config struct {
+	// AdminToken used to authenticate admin endpoints, admin endpoints are disabled when empty.
+	AdminToken string
+	// Maintenance service, a new one that's never refreshed will be used when nil.
+	Maintenance maintenance.Service
+	// Events registry used to publish domain events.
+	// NewMux doesn't subscribe to a given registry, the owner subscribes it once using scores.Subscribe and search.Subscribe,
+	// so the same registry can be shared by multiple mux. When nil, a new registry is created and subscribed.
+	Events events.Service
+	// Diagnostics service used to report sampled queries, sampling is disabled when nil.
+	Diagnostics diagnostics.Service
+	// MaxBodySize in bytes for every request, can be overridden per route using middleware.MaxBodySize.
+	MaxBodySize int64
+	// QueryBudget is the number of database queries a request is expected to execute at most, request that exceeds it is logged.
+	// Budget is disabled when zero, admin always receives the query stats in Server-Timing header.
+	QueryBudget int
+	// Pagination limits of list endpoints, handler.DefaultPagination will be used when max limit is zero.
+	Pagination handler.Pagination
+	// Search engine that indexes todos for /search, search is served by postgres when nil.
+	Search search.Engine
+}
+
 // NewMux api.
-func NewMux(repository rel.Repository) *chi.Mux {
+func NewMux(repository rel.Repository, config Config) *chi.Mux {
+	if config.Maintenance == nil {
+		config.Maintenance = maintenance.New(repository, false, false)
+	}
+
+	if config.Events == nil {
+		config.Events = events.New(repository)
+		scores.Subscribe(config.Events, scores.New(repository))
+		if config.Search != nil {
+			search.Subscribe(config.Events, search.New(repository, config.Search))
+		}
+	}
+
+	if config.Diagnostics == nil {
+		config.Diagnostics = diagnostics.New(nil, 0)
+	}
+
+	if config.MaxBodySize == 0 {
+		config.MaxBodySize = DefaultMaxBodySize
+	}
+
+	if config.Pagination.Max == 0 {
+		config.Pagination.Limits = handler.DefaultPagination.Limits
+	}
+
 	var (
 		mux            = chi.NewMux()
-		scores         = scores.New(repository)
-		todos          = todos.New(repository, scores)
+		todos          = todos.New(repository, config.Events)
+		views          = views.New(repository)
+		search         = search.New(repository, config.Search)
 		healthzHandler = handler.NewHealthz()
-		todosHandler   = handler.NewTodos(repository, todos)
-		scoreHandler   = handler.NewScore(repository)
+		adminHandler   = handler.NewAdmin(config.Maintenance, config.Diagnostics)
+		todosHandler   = handler.NewTodos(repository, todos, config.Pagination)
+		scoreHandler   = handler.NewScore(repository, config.Pagination)
+		viewsHandler   = handler.NewViews(repository, views, config.Pagination)
+		searchHandler  = handler.NewSearch(search, config.Pagination)
 	)
 
 	healthzHandler.Add("database", repository)
@@ -26,11 +110,22 @@ func NewMux(repository rel.Repository) *chi.Mux {
 	mux.Use(chimid.RequestID)
 	mux.Use(chimid.RealIP)
 	mux.Use(chimid.Recoverer)
-	mux.Use(cors.AllowAll().Handler)
+	mux.Use(cors.New(corsOptions).Handler)
+	mux.Use(middleware.MaxBodySize(config.MaxBodySize))
+	mux.Use(middleware.QueryBudget(config.AdminToken, config.QueryBudget))
 
+	// health and admin endpoints stay operational during maintenance.
 	mux.Mount("/healthz", healthzHandler)
-	mux.Mount("/todos", todosHandler)
-	mux.Mount("/score", scoreHandler)
+	mux.With(middleware.Admin(config.AdminToken)).Mount("/admin", adminHandler)
+
+	mux.Group(func(r chi.Router) {
+		r.Use(middleware.Maintenance(config.Maintenance))
+
+		r.Mount("/todos", todosHandler)
+		r.Mount("/score", scoreHandler)
+		r.Mount("/views", viewsHandler)
+		r.Mount("/search", searchHandler)
+	})
 
 	return mux
 }
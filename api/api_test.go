@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fs02/go-todo-backend/api"
+	"github.com/Fs02/go-todo-backend/api/apitest"
+	"github.com/Fs02/go-todo-backend/factories"
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+)
+
+func TestMux_todos(t *testing.T) {
+	h, repository := apitest.New(t)
+
+	repository.ExpectFind(where.Eq("id", 1)).Result(factories.Todo(func(todo *todos.Todo) { todo.ID = 1 }))
+
+	h.Get("/todos/1").Do().
+		AssertStatus(http.StatusOK).
+		AssertHeader("Content-Type", "application/json").
+		AssertJSON(`{"id":1, "title":"Sleep", "completed":false, "order":0, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`)
+}
+
+func TestMux_score(t *testing.T) {
+	h, repository := apitest.New(t)
+
+	repository.ExpectFind().Result(factories.Score(func(score *scores.Score) {
+		score.ID = 1
+		score.TotalPoint = 10
+	}))
+
+	h.Get("/score").Do().
+		AssertStatus(http.StatusOK).
+		AssertJSON(`{"id":1, "total_point":10, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`)
+}
+
+func TestMux_notFound(t *testing.T) {
+	h, _ := apitest.New(t)
+
+	h.Get("/unknown").Do().
+		AssertStatus(http.StatusNotFound)
+}
+
+func TestMux_cors(t *testing.T) {
+	h, _ := apitest.New(t)
+
+	h.Get("/unknown").Header("Origin", "https://todo.example.com").Do().
+		AssertStatus(http.StatusNotFound).
+		AssertHeader("Access-Control-Allow-Origin", "*").
+		AssertHeader("Access-Control-Expose-Headers", "Location, Retry-After, X-Pagination-Limit, X-Pagination-Offset, Server-Timing")
+}
+
+func TestMux_postgres(t *testing.T) {
+	var (
+		h    = apitest.NewPostgres(t)
+		todo todos.Todo
+	)
+
+	h.Post("/todos").JSON(factories.Todo()).Do().
+		AssertStatus(http.StatusCreated).
+		AssertQueries(1).
+		Decode(&todo)
+
+	h.Get(fmt.Sprint("/todos/", todo.ID)).Do().
+		AssertStatus(http.StatusOK).
+		AssertQueries(1).
+		AssertJSON(`{"id":` + fmt.Sprint(todo.ID) + `, "title":"Sleep", "completed":false, "order":0, "url":"todos/` + fmt.Sprint(todo.ID) + `", "created_at":"` + todo.CreatedAt.Format(time.RFC3339Nano) + `", "updated_at":"` + todo.UpdatedAt.Format(time.RFC3339Nano) + `"}`)
+}
+
+func TestMux_maintenance(t *testing.T) {
+	h, repository := apitest.New(t)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectFind(rel.ForUpdate()).NotFound()
+		repository.ExpectInsert().ForType("maintenance.Maintenance")
+	})
+
+	h.Put("/admin/maintenance").Body(`{"enabled": true}`).Do().
+		AssertStatus(http.StatusUnauthorized)
+
+	h.Put("/admin/maintenance").Auth(apitest.AdminToken).Body(`{"enabled": true, "message": "Upgrading database", "retry_after": 60}`).Do().
+		AssertStatus(http.StatusOK)
+
+	h.Get("/todos").Do().
+		AssertStatus(http.StatusServiceUnavailable).
+		AssertHeader("Retry-After", "60").
+		AssertJSON(`{"error":"Service Unavailable", "message":"Upgrading database", "retry_after":60}`)
+
+	h.Get("/healthz").Do().
+		AssertStatus(http.StatusOK)
+
+	h.Get("/admin/maintenance").Auth(apitest.AdminToken).Do().
+		AssertStatus(http.StatusOK)
+}
+
+func TestMux_readOnly(t *testing.T) {
+	h, repository := apitest.New(t)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectFind(rel.ForUpdate()).NotFound()
+		repository.ExpectInsert().ForType("maintenance.Maintenance")
+	})
+
+	h.Put("/admin/maintenance").Auth(apitest.AdminToken).Body(`{"read_only": true, "message": "Failing over database", "retry_after": 60}`).Do().
+		AssertStatus(http.StatusOK)
+
+	repository.ExpectFind().Result(scores.Score{ID: 1})
+	h.Get("/score").Do().
+		AssertStatus(http.StatusOK)
+
+	h.Post("/todos").Body(`{"title": "Sleep"}`).Do().
+		AssertStatus(http.StatusServiceUnavailable).
+		AssertHeader("Retry-After", "60").
+		AssertJSON(`{"error":"Read Only", "message":"Failing over database", "retry_after":60}`)
+}
+
+func TestMux_maxBodySize(t *testing.T) {
+	h, _ := apitest.New(t)
+
+	h.Post("/todos").Body(`{"title": "` + strings.Repeat("z", api.DefaultMaxBodySize) + `"}`).Do().
+		AssertStatus(http.StatusRequestEntityTooLarge).
+		AssertJSON(`{"error":"Request Entity Too Large"}`)
+}
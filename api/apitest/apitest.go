@@ -0,0 +1,321 @@
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api"
+	"github.com/Fs02/go-todo-backend/diagnostics"
+	"github.com/go-rel/postgres"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/reltest"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// DSNEnv is the environment variable that holds postgres dsn used by NewPostgres.
+	DSNEnv = "TEST_POSTGRESQL_DSN"
+	// AdminToken used to authenticate admin endpoints, use it with Request.Auth.
+	AdminToken = "admin-token"
+)
+
+// Option of harness, options are scoped to the harness so parallel tests don't interfere with each other.
+type Option func(*options)
+
+type options struct {
+	config      api.Config
+	queryBudget int
+	golden      golden
+	// counted is set by NewPostgres, reltest repository used by New doesn't execute queries that can be counted.
+	counted bool
+}
+
+// WithConfig used to build router, AdminToken is used when config doesn't set an admin token.
+func WithConfig(config api.Config) Option {
+	return func(o *options) {
+		if config.AdminToken == "" {
+			config.AdminToken = AdminToken
+		}
+
+		o.config = config
+	}
+}
+
+// WithQueryBudget fails the test when a request sent through harness executes more database queries, disabled when zero.
+// Queries are only counted by harness created using NewPostgres, New fails the test when budget is set.
+// Use Response.AssertQueries to check a single request.
+func WithQueryBudget(budget int) Option {
+	return func(o *options) {
+		o.queryBudget = budget
+	}
+}
+
+func buildOptions(opts []Option) options {
+	o := options{
+		config: api.Config{
+			AdminToken: AdminToken,
+		},
+		golden: defaultGolden(),
+	}
+
+	for i := range opts {
+		opts[i](&o)
+	}
+
+	return o
+}
+
+// Harness wires the full api router against a repository for http level tests.
+type Harness struct {
+	t          *testing.T
+	handler    http.Handler
+	options    options
+	Repository rel.Repository
+}
+
+// Get request builder.
+func (h *Harness) Get(path string) *Request {
+	return h.Request("GET", path)
+}
+
+// Post request builder.
+func (h *Harness) Post(path string) *Request {
+	return h.Request("POST", path)
+}
+
+// Put request builder.
+func (h *Harness) Put(path string) *Request {
+	return h.Request("PUT", path)
+}
+
+// Patch request builder.
+func (h *Harness) Patch(path string) *Request {
+	return h.Request("PATCH", path)
+}
+
+// Delete request builder.
+func (h *Harness) Delete(path string) *Request {
+	return h.Request("DELETE", path)
+}
+
+// Request builder for any method.
+func (h *Harness) Request(method string, path string) *Request {
+	return &Request{
+		t:       h.t,
+		handler: h.handler,
+		options: h.options,
+		method:  method,
+		path:    path,
+		header:  make(http.Header),
+	}
+}
+
+// New harness backed by reltest repository.
+// Repository expectations are asserted automatically when the test finished.
+func New(t *testing.T, opts ...Option) (*Harness, *reltest.Repository) {
+	var (
+		repository = reltest.New()
+		options    = buildOptions(opts)
+	)
+
+	if options.queryBudget > 0 {
+		t.Fatal("apitest: query budget requires NewPostgres, queries aren't counted by reltest repository")
+	}
+
+	t.Cleanup(func() {
+		repository.AssertExpectations(t)
+	})
+
+	return &Harness{
+		t:          t,
+		handler:    api.NewMux(repository, options.config),
+		options:    options,
+		Repository: repository,
+	}, repository
+}
+
+// NewPostgres harness backed by a real postgres database.
+// Every test runs inside a transaction that is rolled back when the test finished, so tests can be run in isolation against the same database.
+// Test is skipped when TEST_POSTGRESQL_DSN is not set.
+func NewPostgres(t *testing.T, opts ...Option) *Harness {
+	dsn := os.Getenv(DSNEnv)
+	if dsn == "" {
+		t.Skip(DSNEnv + " is not set")
+	}
+
+	adapter, err := postgres.Open(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := adapter.Begin(context.Background())
+	if err != nil {
+		adapter.Close()
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		tx.Rollback(context.Background())
+		adapter.Close()
+	})
+
+	// nested transaction inside handler will be executed using savepoint.
+	// adapter is wrapped so queries executed by each request can be counted.
+	var (
+		repository = rel.New(diagnostics.Wrap(tx, diagnostics.New(nil, 0)))
+		options    = buildOptions(opts)
+	)
+
+	options.counted = true
+
+	return &Harness{
+		t:          t,
+		handler:    api.NewMux(repository, options.config),
+		options:    options,
+		Repository: repository,
+	}
+}
+
+// Request to be sent to harness router.
+type Request struct {
+	t       *testing.T
+	handler http.Handler
+	options options
+	method  string
+	path    string
+	header  http.Header
+	body    io.Reader
+}
+
+// Header sets request header.
+func (r *Request) Header(key string, value string) *Request {
+	r.header.Set(key, value)
+	return r
+}
+
+// Auth sets bearer token used to authenticate request.
+func (r *Request) Auth(token string) *Request {
+	return r.Header("Authorization", "Bearer "+token)
+}
+
+// Body sets raw request body.
+func (r *Request) Body(body string) *Request {
+	r.body = bytes.NewBufferString(body)
+	return r
+}
+
+// JSON encodes value as request body.
+func (r *Request) JSON(value interface{}) *Request {
+	var (
+		buf = &bytes.Buffer{}
+	)
+
+	if err := json.NewEncoder(buf).Encode(value); err != nil {
+		r.t.Fatal(err)
+	}
+
+	r.body = buf
+	return r.Header("Content-Type", "application/json")
+}
+
+// Do sends request and records the response.
+func (r *Request) Do() *Response {
+	r.t.Helper()
+
+	var (
+		rr       = httptest.NewRecorder()
+		req, err = http.NewRequest(r.method, r.path, r.body)
+	)
+
+	if err != nil {
+		r.t.Fatal(err)
+	}
+
+	ctx, stats := diagnostics.Track(req.Context())
+	req = req.WithContext(ctx)
+	req.Header = r.header
+	r.handler.ServeHTTP(rr, req)
+
+	response := &Response{
+		t:                r.t,
+		options:          r.options,
+		ResponseRecorder: rr,
+		Stats:            stats,
+	}
+
+	if r.options.queryBudget > 0 {
+		response.AssertQueries(r.options.queryBudget)
+	}
+
+	return response
+}
+
+// Response recorded from harness router.
+type Response struct {
+	t       *testing.T
+	options options
+	*httptest.ResponseRecorder
+	// Stats of database queries executed by the request.
+	Stats *diagnostics.Stats
+}
+
+// AssertStatus asserts response status code.
+func (r *Response) AssertStatus(status int) *Response {
+	r.t.Helper()
+	assert.Equal(r.t, status, r.Code)
+	return r
+}
+
+// AssertHeader asserts response header value.
+func (r *Response) AssertHeader(key string, value string) *Response {
+	r.t.Helper()
+	assert.Equal(r.t, value, r.Header().Get(key))
+	return r
+}
+
+// AssertQueries asserts request executes at most budget database queries.
+// The assertion fails when harness isn't created using NewPostgres, since no query is counted.
+func (r *Response) AssertQueries(budget int) *Response {
+	r.t.Helper()
+	assertQueries(r.t, r.options.counted, r.Stats.Queries(), budget)
+	return r
+}
+
+func assertQueries(t assert.TestingT, counted bool, queries int, budget int) bool {
+	if !counted {
+		t.Errorf("apitest: queries are only counted by harness created using NewPostgres")
+		return false
+	}
+
+	return assert.LessOrEqual(t, queries, budget, "query budget exceeded")
+}
+
+// AssertJSON asserts response body is json equivalent to expected.
+func (r *Response) AssertJSON(expected string) *Response {
+	r.t.Helper()
+	assert.JSONEq(r.t, expected, r.Body.String())
+	return r
+}
+
+// AssertEmpty asserts response body is empty.
+func (r *Response) AssertEmpty() *Response {
+	r.t.Helper()
+	assert.Empty(r.t, r.Body.String())
+	return r
+}
+
+// Decode response body into value.
+func (r *Response) Decode(value interface{}) {
+	r.t.Helper()
+
+	if err := json.Unmarshal(r.Body.Bytes(), value); err != nil {
+		r.t.Fatal(err)
+	}
+}
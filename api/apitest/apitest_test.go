@@ -0,0 +1,73 @@
+package apitest
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_options(t *testing.T) {
+	h, _ := New(t, WithConfig(api.Config{MaxBodySize: 8}))
+
+	assert.Equal(t, AdminToken, h.options.config.AdminToken)
+	assert.Equal(t, 1, buildOptions([]Option{WithQueryBudget(1)}).queryBudget)
+
+	h.Post("/todos").Body(`{"title": "Sleep"}`).Do().
+		AssertStatus(http.StatusRequestEntityTooLarge)
+}
+
+type errorRecorder struct {
+	errors []string
+}
+
+func (e *errorRecorder) Errorf(format string, args ...interface{}) {
+	e.errors = append(e.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertQueries(t *testing.T) {
+	tests := []struct {
+		name    string
+		counted bool
+		queries int
+		err     string
+	}{
+		{
+			name:    "within budget",
+			counted: true,
+			queries: 1,
+		},
+		{
+			name:    "exceeded",
+			counted: true,
+			queries: 2,
+			err:     "query budget exceeded",
+		},
+		{
+			name:    "not counted",
+			counted: false,
+			err:     "apitest: queries are only counted by harness created using NewPostgres",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				recorder errorRecorder
+				ok       = assertQueries(&recorder, test.counted, test.queries, 1)
+			)
+
+			if test.err == "" {
+				assert.True(t, ok)
+				assert.Empty(t, recorder.errors)
+				return
+			}
+
+			assert.False(t, ok)
+			assert.Len(t, recorder.errors, 1)
+			assert.Contains(t, recorder.errors[0], test.err)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	// update golden files instead of comparing it, run: go test ./api/... -update
+	update = flag.Bool("update", false, "update golden files")
+)
+
+type golden struct {
+	dir      string
+	keys     []string
+	suffixes []string
+}
+
+func defaultGolden() golden {
+	return golden{
+		dir:      filepath.Join("testdata", "contracts"),
+		keys:     []string{"id"},
+		suffixes: []string{"_id", "_at"},
+	}
+}
+
+// WithGoldenDir where golden files are stored, relative to the test package. Default to testdata/contracts.
+func WithGoldenDir(dir string) Option {
+	return func(o *options) {
+		o.golden.dir = dir
+	}
+}
+
+// WithVolatileKeys normalized in every golden file in addition to "id", because its value changes between runs.
+func WithVolatileKeys(keys ...string) Option {
+	return func(o *options) {
+		o.golden.keys = append(append([]string(nil), o.golden.keys...), keys...)
+	}
+}
+
+// WithVolatileSuffixes normalizes any key ending with one of these suffixes in addition to "_id" and "_at".
+func WithVolatileSuffixes(suffixes ...string) Option {
+	return func(o *options) {
+		o.golden.suffixes = append(append([]string(nil), o.golden.suffixes...), suffixes...)
+	}
+}
+
+// AssertGolden asserts response status and normalized response body against the golden file.
+// Values of volatile fields (ids, timestamps and additional keys) are replaced by its json type, so only the shape of response is compared.
+func (r *Response) AssertGolden(name string, keys ...string) *Response {
+	r.t.Helper()
+
+	var (
+		golden   = r.options.golden
+		path     = filepath.Join(golden.dir, name+".golden.json")
+		contract = map[string]interface{}{
+			"status": r.Code,
+		}
+	)
+
+	if body := r.Body.Bytes(); len(body) > 0 {
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			r.t.Fatal(err)
+		}
+
+		// copy volatile keys, so additional keys never leak into the harness options.
+		golden.keys = append(append([]string(nil), golden.keys...), keys...)
+		contract["body"] = golden.normalize("", value)
+	}
+
+	var (
+		actual  bytes.Buffer
+		encoder = json.NewEncoder(&actual)
+	)
+
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(contract); err != nil {
+		r.t.Fatal(err)
+	}
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			r.t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, actual.Bytes(), 0644); err != nil {
+			r.t.Fatal(err)
+		}
+
+		return r
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		r.t.Fatalf("%s, run tests with -update flag to record it", err)
+	}
+
+	assert.JSONEq(r.t, string(expected), actual.String(), "response contract changed, run tests with -update flag if the change is intended")
+	return r
+}
+
+func (g golden) normalize(key string, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = g.normalize(k, v[k])
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = g.normalize(key, v[i])
+		}
+	case nil:
+		// keep null, it's part of the shape.
+	default:
+		if g.volatile(key) {
+			return "<" + jsonType(v) + ">"
+		}
+	}
+
+	return value
+}
+
+func (g golden) volatile(key string) bool {
+	for _, k := range g.keys {
+		if key == k {
+			return true
+		}
+	}
+
+	for _, suffix := range g.suffixes {
+		if strings.HasSuffix(key, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "string"
+	}
+}
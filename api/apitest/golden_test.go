@@ -0,0 +1,38 @@
+package apitest
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	var (
+		value    interface{}
+		body     = `[{"id":1, "score_id":2, "title":"Sleep", "completed":true, "url":"todos/1", "created_at":"2020-01-01T00:00:00Z", "deleted_at":null}]`
+		expected = `[{"id":"<number>", "score_id":"<number>", "title":"Sleep", "completed":true, "url":"<string>", "created_at":"<string>", "deleted_at":null}]`
+	)
+
+	assert.Nil(t, json.Unmarshal([]byte(body), &value))
+
+	var (
+		options = buildOptions([]Option{WithVolatileKeys("url")})
+	)
+
+	normalized, err := json.Marshal(options.golden.normalize("", value))
+	assert.Nil(t, err)
+	assert.JSONEq(t, expected, string(normalized))
+}
+
+func TestWithVolatileKeys(t *testing.T) {
+	var (
+		first  = buildOptions([]Option{WithVolatileKeys("url")})
+		second = buildOptions([]Option{WithVolatileKeys("title"), WithVolatileSuffixes("_url")})
+	)
+
+	assert.Equal(t, []string{"id", "url"}, first.golden.keys)
+	assert.Equal(t, []string{"id", "title"}, second.golden.keys)
+	assert.Equal(t, []string{"_id", "_at", "_url"}, second.golden.suffixes)
+	assert.Equal(t, []string{"id"}, defaultGolden().keys)
+}
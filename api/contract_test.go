@@ -0,0 +1,291 @@
+package api_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api"
+	"github.com/Fs02/go-todo-backend/api/apitest"
+	"github.com/Fs02/go-todo-backend/factories"
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/Fs02/go-todo-backend/views"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+)
+
+// Contract tests records the shape of every endpoint response in testdata/contracts.
+// Any change to the response shape will break clients, run `go test ./api/ -update` only when the change is intended.
+func TestContract(t *testing.T) {
+	var (
+		todo = factories.Todo(func(todo *todos.Todo) { todo.ID = 1 })
+		view = views.View{ID: 1, Name: "Recent", Sort: "-updated_at", Fields: "title,completed"}
+	)
+
+	tests := []struct {
+		name     string
+		setup    func(h *apitest.Harness, repo *reltest.Repository)
+		request  func(h *apitest.Harness) *apitest.Request
+		mockRepo func(repo *reltest.Repository)
+	}{
+		{
+			name: "healthz_show",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/healthz")
+			},
+		},
+		{
+			name: "todos_index",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/todos")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFindAll(rel.Select().SortAsc("order").SortAsc("id").Limit(100)).Result([]todos.Todo{todo})
+			},
+		},
+		{
+			name: "todos_create",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Post("/todos").JSON(factories.Todo())
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectTransaction(func(repo *reltest.Repository) {
+					repo.ExpectInsert().ForType("todos.Todo")
+				})
+			},
+		},
+		{
+			name: "todos_create_bad_request",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Post("/todos").Body("{")
+			},
+		},
+		{
+			name: "todos_create_too_large",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Post("/todos").Body(`{"title": "` + strings.Repeat("z", api.DefaultMaxBodySize) + `"}`)
+			},
+		},
+		{
+			name: "todos_create_unprocessable",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Post("/todos").Body(`{"title": ""}`)
+			},
+		},
+		{
+			name: "todos_show",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/todos/1")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(todo)
+			},
+		},
+		{
+			name: "todos_show_not_found",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/todos/1")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).NotFound()
+			},
+		},
+		{
+			name: "todos_update",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Patch("/todos/1").Body(`{"title": "Wake"}`)
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(todo)
+				repo.ExpectUpdate().ForType("todos.Todo")
+			},
+		},
+		{
+			name: "todos_destroy",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Delete("/todos/1")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(todo)
+				repo.ExpectDelete().ForType("todos.Todo")
+			},
+		},
+		{
+			name: "todos_clear",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Delete("/todos")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectDeleteAny(rel.From("todos")).Unsafe()
+			},
+		},
+		{
+			name: "score_index",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/score")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind().Result(factories.Score(func(score *scores.Score) { score.ID = 1 }))
+			},
+		},
+		{
+			name: "score_points",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/score/points")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFindAll(rel.SortAsc("id"), rel.Limit(100), rel.Offset(0)).Result([]scores.Point{factories.Point(func(point *scores.Point) { point.ID = 1 })})
+			},
+		},
+		{
+			name: "todos_index_view",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/todos?view=1")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(view)
+				repo.ExpectFindAll(rel.Select().SortDesc("updated_at").SortAsc("id").Limit(100)).Result([]todos.Todo{todo})
+			},
+		},
+		{
+			name: "views_index",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/views")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFindAll(rel.SortAsc("id"), rel.Limit(100), rel.Offset(0)).Result([]views.View{view})
+			},
+		},
+		{
+			name: "views_create",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Post("/views").Body(`{"name": "Recent", "sort": "-updated_at", "fields": "title,completed"}`)
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectInsert().ForType("views.View")
+			},
+		},
+		{
+			name: "views_create_unprocessable",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Post("/views").Body(`{"name": "Recent", "sort": "secret"}`)
+			},
+		},
+		{
+			name: "views_show",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/views/1")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(view)
+			},
+		},
+		{
+			name: "views_update",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Patch("/views/1").Body(`{"keyword": "Wake"}`)
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(view)
+				repo.ExpectUpdate().ForType("views.View")
+			},
+		},
+		{
+			name: "views_destroy",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Delete("/views/1")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(view)
+				repo.ExpectDelete().ForType("views.View")
+			},
+		},
+		{
+			name: "search_index",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/search?q=Sleep")
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				matches := rel.Where(where.Fragment("title ILIKE ?", "%Sleep%"))
+				repo.ExpectFindAll(matches.SortAsc("order").SortAsc("id").Limit(100)).Result([]todos.Todo{todo})
+				repo.ExpectCount("todos", matches).Result(1)
+				repo.ExpectCount("todos", matches.Where(where.Eq("completed", true))).Result(0)
+			},
+		},
+		{
+			name: "admin_unauthorized",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/admin/maintenance")
+			},
+		},
+		{
+			name: "admin_show_maintenance",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/admin/maintenance").Auth(apitest.AdminToken)
+			},
+		},
+		{
+			name: "admin_update_maintenance",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Put("/admin/maintenance").Auth(apitest.AdminToken).Body(`{"enabled": true}`)
+			},
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectTransaction(func(repo *reltest.Repository) {
+					repo.ExpectFind(rel.ForUpdate()).NotFound()
+					repo.ExpectInsert().ForType("maintenance.Maintenance")
+				})
+			},
+		},
+		{
+			name: "admin_query_insights",
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/admin/query-insights").Auth(apitest.AdminToken)
+			},
+		},
+		{
+			name: "maintenance_read_only",
+			setup: func(h *apitest.Harness, repo *reltest.Repository) {
+				repo.ExpectTransaction(func(repo *reltest.Repository) {
+					repo.ExpectFind(rel.ForUpdate()).NotFound()
+					repo.ExpectInsert().ForType("maintenance.Maintenance")
+				})
+
+				h.Put("/admin/maintenance").Auth(apitest.AdminToken).Body(`{"read_only": true}`).Do()
+			},
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Post("/todos").JSON(factories.Todo())
+			},
+		},
+		{
+			name: "maintenance_unavailable",
+			setup: func(h *apitest.Harness, repo *reltest.Repository) {
+				repo.ExpectTransaction(func(repo *reltest.Repository) {
+					repo.ExpectFind(rel.ForUpdate()).NotFound()
+					repo.ExpectInsert().ForType("maintenance.Maintenance")
+				})
+
+				h.Put("/admin/maintenance").Auth(apitest.AdminToken).Body(`{"enabled": true}`).Do()
+			},
+			request: func(h *apitest.Harness) *apitest.Request {
+				return h.Get("/todos")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h, repository := apitest.New(t, apitest.WithVolatileKeys("url"))
+
+			if test.setup != nil {
+				test.setup(h, repository)
+			}
+
+			if test.mockRepo != nil {
+				test.mockRepo(repository)
+			}
+
+			test.request(h).Do().AssertGolden(test.name)
+		})
+	}
+}
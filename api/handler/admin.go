@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Fs02/go-todo-backend/diagnostics"
+	"github.com/Fs02/go-todo-backend/maintenance"
+	"github.com/go-chi/chi"
+)
+
+// Admin for admin endpoints.
+type Admin struct {
+	*chi.Mux
+	maintenance maintenance.Service
+	diagnostics diagnostics.Service
+}
+
+// ShowMaintenance handle GET /maintenance
+func (a Admin) ShowMaintenance(w http.ResponseWriter, r *http.Request) {
+	render(w, a.maintenance.Status(), 200)
+}
+
+// UpdateMaintenance handle PUT /maintenance
+func (a Admin) UpdateMaintenance(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		status maintenance.Maintenance
+	)
+
+	if !decode(w, r, &status) {
+		return
+	}
+
+	if err := a.maintenance.Update(ctx, &status); err != nil {
+		render(w, err, 422)
+		return
+	}
+
+	render(w, a.maintenance.Status(), 200)
+}
+
+// QueryInsights handle GET /query-insights, returns 20 most expensive sampled queries unless limit is given.
+func (a Admin) QueryInsights(w http.ResponseWriter, r *http.Request) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+
+	render(w, a.diagnostics.Report(limit), 200)
+}
+
+// NewAdmin handler.
+func NewAdmin(maintenance maintenance.Service, diagnostics diagnostics.Service) Admin {
+	h := Admin{
+		Mux:         chi.NewMux(),
+		maintenance: maintenance,
+		diagnostics: diagnostics,
+	}
+
+	h.Get("/maintenance", h.ShowMaintenance)
+	h.Put("/maintenance", h.UpdateMaintenance)
+	h.Get("/query-insights", h.QueryInsights)
+
+	return h
+}
@@ -0,0 +1,169 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api/handler"
+	"github.com/Fs02/go-todo-backend/diagnostics"
+	"github.com/Fs02/go-todo-backend/diagnostics/diagnosticstest"
+	"github.com/Fs02/go-todo-backend/maintenance"
+	"github.com/Fs02/go-todo-backend/maintenance/maintenancetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmin_ShowMaintenance(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		path            string
+		response        string
+		mockMaintenance func(service *maintenancetest.Service)
+	}{
+		{
+			name:            "ok",
+			status:          http.StatusOK,
+			path:            "/maintenance",
+			response:        `{"enabled":true, "read_only":false, "message":"Upgrading database", "retry_after":60, "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockMaintenance: maintenancetest.MockStatus(maintenance.Maintenance{Enabled: true, Message: "Upgrading database", RetryAfter: 60}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				req, _      = http.NewRequest("GET", test.path, nil)
+				rr          = httptest.NewRecorder()
+				maintenance = &maintenancetest.Service{}
+				handler     = handler.NewAdmin(maintenance, nil)
+			)
+
+			maintenancetest.Mock(maintenance, test.mockMaintenance)
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			maintenance.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdmin_UpdateMaintenance(t *testing.T) {
+	tests := []struct {
+		name                  string
+		status                int
+		path                  string
+		payload               string
+		response              string
+		mockMaintenanceUpdate func(service *maintenancetest.Service)
+		mockMaintenanceStatus func(service *maintenancetest.Service)
+	}{
+		{
+			name:                  "ok",
+			status:                http.StatusOK,
+			path:                  "/maintenance",
+			payload:               `{"enabled": true}`,
+			response:              `{"enabled":true, "read_only":false, "message":"Service is under maintenance, please try again later", "retry_after":300, "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockMaintenanceUpdate: maintenancetest.MockUpdate(maintenance.Maintenance{Enabled: true}, nil),
+			mockMaintenanceStatus: maintenancetest.MockStatus(maintenance.Maintenance{Enabled: true}),
+		},
+		{
+			name:                  "read only",
+			status:                http.StatusOK,
+			path:                  "/maintenance",
+			payload:               `{"read_only": true, "message": "Failing over database"}`,
+			response:              `{"enabled":false, "read_only":true, "message":"Failing over database", "retry_after":300, "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockMaintenanceUpdate: maintenancetest.MockUpdate(maintenance.Maintenance{ReadOnly: true, Message: "Failing over database"}, nil),
+			mockMaintenanceStatus: maintenancetest.MockStatus(maintenance.Maintenance{ReadOnly: true, Message: "Failing over database"}),
+		},
+		{
+			name:                  "validation error",
+			status:                http.StatusUnprocessableEntity,
+			path:                  "/maintenance",
+			payload:               `{"enabled": true, "retry_after": -1}`,
+			response:              `{"error":"Retry after can't be negative"}`,
+			mockMaintenanceUpdate: maintenancetest.MockUpdate(maintenance.Maintenance{Enabled: true, RetryAfter: -1}, maintenance.ErrMaintenanceRetryAfterInvalid),
+		},
+		{
+			name:     "bad request",
+			status:   http.StatusBadRequest,
+			path:     "/maintenance",
+			payload:  ``,
+			response: `{"error":"Bad Request"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				body        = strings.NewReader(test.payload)
+				req, _      = http.NewRequest("PUT", test.path, body)
+				rr          = httptest.NewRecorder()
+				maintenance = &maintenancetest.Service{}
+				handler     = handler.NewAdmin(maintenance, nil)
+			)
+
+			maintenancetest.Mock(maintenance, test.mockMaintenanceUpdate, test.mockMaintenanceStatus)
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			maintenance.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdmin_QueryInsights(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		response        string
+		mockDiagnostics func(service *diagnosticstest.Service)
+	}{
+		{
+			name:     "default limit",
+			path:     "/query-insights",
+			response: `{"rate":0.1, "sampled":1, "explained":1, "dropped":0, "failed":0, "queries":[{"route":"GET /todos/", "statement":"SELECT * FROM todos;", "samples":1, "cost":180.5, "rows":40, "seq_scans":["todos"], "plan":[], "explained_at":"0001-01-01T00:00:00Z"}]}`,
+			mockDiagnostics: diagnosticstest.MockReport(20, diagnostics.Report{
+				Rate:      0.1,
+				Sampled:   1,
+				Explained: 1,
+				Queries: []diagnostics.Insight{
+					{Route: "GET /todos/", Statement: "SELECT * FROM todos;", Samples: 1, Cost: 180.5, Rows: 40, SeqScans: []string{"todos"}, Plan: []byte(`[]`)},
+				},
+			}),
+		},
+		{
+			name:            "with limit",
+			path:            "/query-insights?limit=5",
+			response:        `{"rate":0, "sampled":0, "explained":0, "dropped":0, "failed":0, "queries":[]}`,
+			mockDiagnostics: diagnosticstest.MockReport(5, diagnostics.Report{Queries: []diagnostics.Insight{}}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				req, _      = http.NewRequest("GET", test.path, nil)
+				rr          = httptest.NewRecorder()
+				diagnostics = &diagnosticstest.Service{}
+				handler     = handler.NewAdmin(nil, diagnostics)
+			)
+
+			diagnosticstest.Mock(diagnostics, test.mockDiagnostics)
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			diagnostics.AssertExpectations(t)
+		})
+	}
+}
@@ -12,8 +12,29 @@ var (
 	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "handler")))
 	// ErrBadRequest error.
 	ErrBadRequest = errors.New("Bad Request")
+	// ErrRequestEntityTooLarge error.
+	ErrRequestEntityTooLarge = errors.New("Request Entity Too Large")
 )
 
+// decode json request body, renders error response and returns false when body can't be decoded.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == nil {
+		return true
+	}
+
+	logger.Warn("decode error", zap.Error(err))
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		render(w, ErrRequestEntityTooLarge, 413)
+	} else {
+		render(w, ErrBadRequest, 400)
+	}
+
+	return false
+}
+
 func render(w http.ResponseWriter, body interface{}, status int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
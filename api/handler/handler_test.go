@@ -2,7 +2,9 @@ package handler
 
 import (
 	"errors"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -53,3 +55,56 @@ func TestRender(t *testing.T) {
 		})
 	}
 }
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		limit    int64
+		decoded  bool
+		status   int
+		response string
+	}{
+		{
+			name:    "ok",
+			body:    `{"id":1}`,
+			limit:   10,
+			decoded: true,
+			status:  200,
+		},
+		{
+			name:     "bad request",
+			body:     `{`,
+			limit:    10,
+			status:   400,
+			response: `{"error":"Bad Request"}`,
+		},
+		{
+			name:     "request entity too large",
+			body:     `{"id":100}`,
+			limit:    5,
+			status:   413,
+			response: `{"error":"Request Entity Too Large"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				rr     = httptest.NewRecorder()
+				req, _ = http.NewRequest("POST", "/", strings.NewReader(test.body))
+				v      struct {
+					ID int `json:"id"`
+				}
+			)
+
+			req.Body = http.MaxBytesReader(rr, req.Body, test.limit)
+
+			assert.Equal(t, test.decoded, decode(rr, req, &v))
+			assert.Equal(t, test.status, rr.Code)
+			if test.response != "" {
+				assert.JSONEq(t, test.response, rr.Body.String())
+			}
+		})
+	}
+}
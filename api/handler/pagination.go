@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PaginationLimitHeader contains the limit applied to a list response.
+	PaginationLimitHeader = "X-Pagination-Limit"
+	// PaginationOffsetHeader contains the offset applied to a list response.
+	PaginationOffsetHeader = "X-Pagination-Offset"
+)
+
+// DefaultPagination used when config doesn't specify pagination.
+var DefaultPagination = Pagination{
+	Limits: Limits{Default: 100, Max: 1000},
+}
+
+// Limits of a list endpoint.
+type Limits struct {
+	// Default limit used when client doesn't specify ?limit=.
+	Default int
+	// Max limit, larger limit requested by client is clamped to this value.
+	Max int
+}
+
+// Pagination policy for every list endpoints.
+type Pagination struct {
+	Limits
+	// Resources overrides limits per resource, zero value falls back to the global limits.
+	Resources map[string]Limits
+}
+
+// For returns limits of a resource.
+func (p Pagination) For(resource string) Limits {
+	limits := p.Limits
+
+	if override, ok := p.Resources[resource]; ok {
+		if override.Default > 0 {
+			limits.Default = override.Default
+		}
+
+		if override.Max > 0 {
+			limits.Max = override.Max
+		}
+	}
+
+	if limits.Default > limits.Max {
+		limits.Default = limits.Max
+	}
+
+	return limits
+}
+
+// ParsePagination parses pagination config in the form of "default:max,resource=default:max".
+// Global limits that aren't specified fall back to DefaultPagination.
+// eg: "50:500,points=200:2000".
+func ParsePagination(config string) (Pagination, error) {
+	pagination := Pagination{
+		Limits:    DefaultPagination.Limits,
+		Resources: make(map[string]Limits),
+	}
+
+	for _, part := range strings.Split(config, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+
+		resource, value, override := strings.Cut(part, "=")
+		if !override {
+			resource, value = "", part
+		}
+
+		limits, err := parseLimits(value)
+		if err != nil {
+			return Pagination{}, fmt.Errorf("invalid pagination %q: %w", part, err)
+		}
+
+		if override {
+			pagination.Resources[strings.TrimSpace(resource)] = limits
+			continue
+		}
+
+		if limits.Default > 0 {
+			pagination.Default = limits.Default
+		}
+
+		if limits.Max > 0 {
+			pagination.Max = limits.Max
+		}
+	}
+
+	return pagination, nil
+}
+
+func parseLimits(value string) (Limits, error) {
+	var (
+		limits               Limits
+		defaults, maximum, _ = strings.Cut(value, ":")
+		err                  error
+	)
+
+	if defaults = strings.TrimSpace(defaults); defaults != "" {
+		if limits.Default, err = strconv.Atoi(defaults); err != nil || limits.Default <= 0 {
+			return limits, errors.New("default limit must be a positive number")
+		}
+	}
+
+	if maximum = strings.TrimSpace(maximum); maximum != "" {
+		if limits.Max, err = strconv.Atoi(maximum); err != nil || limits.Max <= 0 {
+			return limits, errors.New("max limit must be a positive number")
+		}
+	}
+
+	return limits, nil
+}
+
+// Page of a list requested using ?limit= and ?offset=.
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// Page parses page from request query, limit is clamped to the max limit.
+// Invalid limit falls back to the default limit and invalid offset falls back to zero.
+func (l Limits) Page(r *http.Request) Page {
+	var (
+		query = r.URL.Query()
+		page  = Page{Limit: l.Default}
+	)
+
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 {
+		page.Limit = limit
+	}
+
+	if l.Max > 0 && page.Limit > l.Max {
+		page.Limit = l.Max
+	}
+
+	if offset, err := strconv.Atoi(query.Get("offset")); err == nil && offset > 0 {
+		page.Offset = offset
+	}
+
+	return page
+}
+
+// Header writes the applied page to response header, so client knows the limit actually used.
+// It must be called before the response is rendered.
+func (p Page) Header(w http.ResponseWriter) {
+	w.Header().Set(PaginationLimitHeader, strconv.Itoa(p.Limit))
+	w.Header().Set(PaginationOffsetHeader, strconv.Itoa(p.Offset))
+}
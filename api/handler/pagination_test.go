@@ -0,0 +1,123 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPagination_For(t *testing.T) {
+	var (
+		pagination = handler.Pagination{
+			Limits: handler.Limits{Default: 100, Max: 1000},
+			Resources: map[string]handler.Limits{
+				"points": {Default: 500, Max: 5000},
+				"views":  {Max: 50},
+			},
+		}
+	)
+
+	assert.Equal(t, handler.Limits{Default: 100, Max: 1000}, pagination.For("todos"))
+	assert.Equal(t, handler.Limits{Default: 500, Max: 5000}, pagination.For("points"))
+	assert.Equal(t, handler.Limits{Default: 50, Max: 50}, pagination.For("views"))
+}
+
+func TestParsePagination(t *testing.T) {
+	tests := []struct {
+		config     string
+		pagination handler.Pagination
+		err        string
+	}{
+		{
+			config: "",
+			pagination: handler.Pagination{
+				Limits:    handler.DefaultPagination.Limits,
+				Resources: map[string]handler.Limits{},
+			},
+		},
+		{
+			config: "50:500, points=200:2000,views=:20",
+			pagination: handler.Pagination{
+				Limits: handler.Limits{Default: 50, Max: 500},
+				Resources: map[string]handler.Limits{
+					"points": {Default: 200, Max: 2000},
+					"views":  {Max: 20},
+				},
+			},
+		},
+		{
+			config: ":5000",
+			pagination: handler.Pagination{
+				Limits:    handler.Limits{Default: 100, Max: 5000},
+				Resources: map[string]handler.Limits{},
+			},
+		},
+		{
+			config: "todos=ten:100",
+			err:    `invalid pagination "todos=ten:100": default limit must be a positive number`,
+		},
+		{
+			config: "10:-1",
+			err:    `invalid pagination "10:-1": max limit must be a positive number`,
+		},
+		{
+			config: "0:100",
+			err:    `invalid pagination "0:100": default limit must be a positive number`,
+		},
+		{
+			config: "views=10:0",
+			err:    `invalid pagination "views=10:0": max limit must be a positive number`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.config, func(t *testing.T) {
+			pagination, err := handler.ParsePagination(test.config)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.pagination, pagination)
+		})
+	}
+}
+
+func TestLimits_Page(t *testing.T) {
+	var (
+		limits = handler.Limits{Default: 100, Max: 1000}
+	)
+
+	tests := []struct {
+		path string
+		page handler.Page
+	}{
+		{path: "/", page: handler.Page{Limit: 100}},
+		{path: "/?limit=10&offset=20", page: handler.Page{Limit: 10, Offset: 20}},
+		{path: "/?limit=100000", page: handler.Page{Limit: 1000}},
+		{path: "/?limit=0&offset=-1", page: handler.Page{Limit: 100}},
+		{path: "/?limit=all&offset=first", page: handler.Page{Limit: 100}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", test.path, nil)
+			assert.Equal(t, test.page, limits.Page(req))
+		})
+	}
+}
+
+func TestPage_Header(t *testing.T) {
+	var (
+		rr = httptest.NewRecorder()
+	)
+
+	handler.Page{Limit: 10, Offset: 20}.Header(rr)
+
+	assert.Equal(t, "10", rr.Header().Get(handler.PaginationLimitHeader))
+	assert.Equal(t, "20", rr.Header().Get(handler.PaginationOffsetHeader))
+}
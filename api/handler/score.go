@@ -12,6 +12,7 @@ import (
 type Score struct {
 	*chi.Mux
 	repository rel.Repository
+	limits     Limits
 }
 
 // Index handle GET /
@@ -29,18 +30,24 @@ func (s Score) Index(w http.ResponseWriter, r *http.Request) {
 func (s Score) Points(w http.ResponseWriter, r *http.Request) {
 	var (
 		ctx    = r.Context()
+		page   = s.limits.Page(r)
 		result []scores.Point
 	)
 
-	s.repository.FindAll(ctx, &result)
+	if err := s.repository.FindAll(ctx, &result, rel.SortAsc("id"), rel.Limit(page.Limit), rel.Offset(page.Offset)); err != nil {
+		panic(err)
+	}
+
+	page.Header(w)
 	render(w, result, 200)
 }
 
 // NewScore handler.
-func NewScore(repository rel.Repository) Score {
+func NewScore(repository rel.Repository, pagination Pagination) Score {
 	h := Score{
 		Mux:        chi.NewMux(),
 		repository: repository,
+		limits:     pagination.For("points"),
 	}
 
 	h.Get("/", h.Index)
@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"github.com/Fs02/go-todo-backend/api/handler"
+	"github.com/Fs02/go-todo-backend/factories"
 	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/go-rel/rel"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,7 +27,10 @@ func TestScore_Index(t *testing.T) {
 			path:     "/",
 			response: `{"id":1, "total_point":10, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind().Result(scores.Score{ID: 1, TotalPoint: 10})
+				repo.ExpectFind().Result(factories.Score(func(score *scores.Score) {
+					score.ID = 1
+					score.TotalPoint = 10
+				}))
 			},
 		},
 	}
@@ -36,7 +41,7 @@ func TestScore_Index(t *testing.T) {
 				req, _     = http.NewRequest("GET", test.path, nil)
 				rr         = httptest.NewRecorder()
 				repository = reltest.New()
-				handler    = handler.NewScore(repository)
+				handler    = handler.NewScore(repository, handler.DefaultPagination)
 			)
 
 			if test.mockRepo != nil {
@@ -66,7 +71,7 @@ func TestScore_Points(t *testing.T) {
 			path:     "/points",
 			response: `[{"id":1, "name": "todo completed", "count":1, "score_id": 0, "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFindAll().Result([]scores.Point{{ID: 1, Name: "todo completed", Count: 1}})
+				repo.ExpectFindAll(rel.SortAsc("id"), rel.Limit(100), rel.Offset(0)).Result([]scores.Point{factories.Point(func(point *scores.Point) { point.ID = 1 })})
 			},
 		},
 	}
@@ -77,7 +82,7 @@ func TestScore_Points(t *testing.T) {
 				req, _     = http.NewRequest("GET", test.path, nil)
 				rr         = httptest.NewRecorder()
 				repository = reltest.New()
-				handler    = handler.NewScore(repository)
+				handler    = handler.NewScore(repository, handler.DefaultPagination)
 			)
 
 			if test.mockRepo != nil {
@@ -92,3 +97,20 @@ func TestScore_Points(t *testing.T) {
 		})
 	}
 }
+
+func TestScore_Points_error(t *testing.T) {
+	var (
+		req, _     = http.NewRequest("GET", "/points", nil)
+		rr         = httptest.NewRecorder()
+		repository = reltest.New()
+		handler    = handler.NewScore(repository, handler.DefaultPagination)
+	)
+
+	repository.ExpectFindAll(rel.SortAsc("id"), rel.Limit(100), rel.Offset(0)).ConnectionClosed()
+
+	assert.Panics(t, func() {
+		handler.ServeHTTP(rr, req)
+	})
+
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Fs02/go-todo-backend/search"
+	"github.com/go-chi/chi"
+)
+
+// Search for search endpoint.
+type Search struct {
+	*chi.Mux
+	search search.Service
+	limits Limits
+}
+
+// Index handle GET /.
+// Todos are searched using ?q= and filtered using ?completed=, result contains number of matching todos for each facet.
+// Result is paginated using ?limit= and ?offset=, the applied values are returned in X-Pagination-* headers.
+func (s Search) Index(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		params = r.URL.Query()
+		page   = s.limits.Page(r)
+		result search.Result
+		query  = search.Query{
+			Keyword: params.Get("q"),
+			Limit:   page.Limit,
+			Offset:  page.Offset,
+		}
+	)
+
+	if str := params.Get("completed"); str != "" {
+		completed := str == "true"
+		query.Completed = &completed
+	}
+
+	if err := s.search.Search(ctx, &result, query); err != nil {
+		render(w, err, 422)
+		return
+	}
+
+	page.Header(w)
+	render(w, result, 200)
+}
+
+// NewSearch handler.
+func NewSearch(search search.Service, pagination Pagination) Search {
+	h := Search{
+		Mux:    chi.NewMux(),
+		search: search,
+		limits: pagination.For("search"),
+	}
+
+	h.Get("/", h.Index)
+
+	return h
+}
@@ -0,0 +1,78 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api/handler"
+	"github.com/Fs02/go-todo-backend/search"
+	"github.com/Fs02/go-todo-backend/search/searchtest"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch_Index(t *testing.T) {
+	var (
+		completed = true
+	)
+
+	tests := []struct {
+		name       string
+		path       string
+		status     int
+		response   string
+		limit      string
+		mockSearch func(search *searchtest.Service)
+	}{
+		{
+			name:     "ok",
+			path:     "/?q=slep&completed=true&limit=10",
+			status:   http.StatusOK,
+			response: `{"todos":[{"id":1, "title":"Sleep", "order":0, "completed":true, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}], "total":1, "facets":{"completed":{"true":1}}, "source":"meilisearch"}`,
+			limit:    "10",
+			mockSearch: searchtest.MockSearch(
+				search.Result{
+					Todos:  []todos.Todo{{ID: 1, Title: "Sleep", Completed: true}},
+					Total:  1,
+					Facets: map[string]map[string]int{"completed": {"true": 1}},
+					Source: "meilisearch",
+				},
+				search.Query{Keyword: "slep", Completed: &completed, Limit: 10},
+				nil,
+			),
+		},
+		{
+			name:     "validation error",
+			path:     "/?q=long",
+			status:   http.StatusUnprocessableEntity,
+			response: `{"error":"Keyword is too long"}`,
+			mockSearch: searchtest.MockSearch(
+				search.Result{},
+				search.Query{Keyword: "long", Limit: 100},
+				search.ErrKeywordTooLong,
+			),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				req, _  = http.NewRequest("GET", test.path, nil)
+				rr      = httptest.NewRecorder()
+				service = &searchtest.Service{}
+				handler = handler.NewSearch(service, handler.DefaultPagination)
+			)
+
+			searchtest.Mock(service, test.mockSearch)
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.Equal(t, test.limit, rr.Header().Get("X-Pagination-Limit"))
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			service.AssertExpectations(t)
+		})
+	}
+}
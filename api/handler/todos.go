@@ -9,10 +9,10 @@ import (
 	"strconv"
 
 	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/Fs02/go-todo-backend/views"
 	"github.com/go-chi/chi"
 	"github.com/go-rel/rel"
 	"github.com/go-rel/rel/where"
-	"go.uber.org/zap"
 )
 
 type ctx int
@@ -27,25 +27,71 @@ type Todos struct {
 	*chi.Mux
 	repository rel.Repository
 	todos      todos.Service
+	limits     Limits
 }
 
 // Index handle GET /.
+// Search can be loaded from a saved view using ?view={id}, other query parameters overrides the saved one.
+// Result is paginated using ?limit= and ?offset=, the applied values are returned in X-Pagination-* headers.
 func (t Todos) Index(w http.ResponseWriter, r *http.Request) {
 	var (
 		ctx    = r.Context()
 		query  = r.URL.Query()
+		page   = t.limits.Page(r)
 		result []todos.Todo
-		filter = todos.Filter{
-			Keyword: query.Get("keyword"),
-		}
+		view   views.View
 	)
 
+	if str := query.Get("view"); str != "" {
+		id, _ := strconv.Atoi(str)
+		if err := t.repository.Find(ctx, &view, where.Eq("id", id)); err != nil {
+			if errors.Is(err, rel.ErrNotFound) {
+				render(w, err, 404)
+				return
+			}
+			panic(err)
+		}
+	}
+
+	if query.Has("keyword") {
+		view.Keyword = query.Get("keyword")
+	}
+
 	if str := query.Get("completed"); str != "" {
 		completed := str == "true"
-		filter.Completed = &completed
+		view.Completed = &completed
+	}
+
+	if query.Has("sort") {
+		view.Sort = query.Get("sort")
+	}
+
+	if query.Has("fields") {
+		view.Fields = query.Get("fields")
+	}
+
+	fields := view.FieldList()
+	if err := todos.ValidateFields(fields); err != nil {
+		render(w, err, 422)
+		return
+	}
+
+	filter := view.Filter()
+	filter.Limit = page.Limit
+	filter.Offset = page.Offset
+
+	if err := t.todos.Search(ctx, &result, filter); err != nil {
+		render(w, err, 422)
+		return
+	}
+
+	page.Header(w)
+
+	if len(fields) > 0 {
+		render(w, selectFields(result, fields), 200)
+		return
 	}
 
-	t.todos.Search(ctx, &result, filter)
 	render(w, result, 200)
 }
 
@@ -56,9 +102,7 @@ func (t Todos) Create(w http.ResponseWriter, r *http.Request) {
 		todo todos.Todo
 	)
 
-	if err := json.NewDecoder(r.Body).Decode(&todo); err != nil {
-		logger.Warn("decode error", zap.Error(err))
-		render(w, ErrBadRequest, 400)
+	if !decode(w, r, &todo) {
 		return
 	}
 
@@ -89,9 +133,7 @@ func (t Todos) Update(w http.ResponseWriter, r *http.Request) {
 		changes = rel.NewChangeset(&todo)
 	)
 
-	if err := json.NewDecoder(r.Body).Decode(&todo); err != nil {
-		logger.Warn("decode error", zap.Error(err))
-		render(w, ErrBadRequest, 400)
+	if !decode(w, r, &todo) {
 		return
 	}
 
@@ -146,12 +188,34 @@ func (t Todos) Load(next http.Handler) http.Handler {
 	})
 }
 
+// selectFields encodes todos with only the selected fields.
+func selectFields(result []todos.Todo, fields []string) []map[string]json.RawMessage {
+	selected := make([]map[string]json.RawMessage, len(result))
+
+	for i := range result {
+		var (
+			encoded, _ = json.Marshal(result[i])
+			all        map[string]json.RawMessage
+		)
+
+		json.Unmarshal(encoded, &all)
+
+		selected[i] = make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			selected[i][field] = all[field]
+		}
+	}
+
+	return selected
+}
+
 // NewTodos handler.
-func NewTodos(repository rel.Repository, todos todos.Service) Todos {
+func NewTodos(repository rel.Repository, todos todos.Service, pagination Pagination) Todos {
 	h := Todos{
 		Mux:        chi.NewMux(),
 		repository: repository,
 		todos:      todos,
+		limits:     pagination.For("todos"),
 	}
 
 	h.Get("/", h.Index)
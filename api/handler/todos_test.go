@@ -7,8 +7,10 @@ import (
 	"testing"
 
 	"github.com/Fs02/go-todo-backend/api/handler"
+	"github.com/Fs02/go-todo-backend/factories"
 	"github.com/Fs02/go-todo-backend/todos"
 	"github.com/Fs02/go-todo-backend/todos/todostest"
+	"github.com/Fs02/go-todo-backend/views"
 	"github.com/go-rel/rel/where"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
@@ -24,6 +26,9 @@ func TestTodos_Index(t *testing.T) {
 		status          int
 		path            string
 		response        string
+		limit           string
+		offset          string
+		mockRepo        func(repo *reltest.Repository)
 		mockTodosSearch func(todos *todostest.Service)
 	}{
 		{
@@ -31,9 +36,11 @@ func TestTodos_Index(t *testing.T) {
 			status:   http.StatusOK,
 			path:     "/",
 			response: `[{"id":1, "title":"Sleep", "completed":false, "order":0, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
+			limit:    "100",
+			offset:   "0",
 			mockTodosSearch: todostest.MockSearch(
-				[]todos.Todo{{ID: 1, Title: "Sleep"}},
-				todos.Filter{},
+				[]todos.Todo{factories.Todo(func(todo *todos.Todo) { todo.ID = 1 })},
+				todos.Filter{Limit: 100},
 				nil,
 			),
 		},
@@ -42,12 +49,82 @@ func TestTodos_Index(t *testing.T) {
 			status:   http.StatusOK,
 			path:     "/?keyword=Wake&completed=true",
 			response: `[{"id":2, "title":"Wake", "completed":true, "order":0, "url":"todos/2", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`,
+			limit:    "100",
+			offset:   "0",
+			mockTodosSearch: todostest.MockSearch(
+				[]todos.Todo{{ID: 2, Title: "Wake", Completed: true}},
+				todos.Filter{Keyword: "Wake", Completed: &trueb, Limit: 100},
+				nil,
+			),
+		},
+		{
+			name:     "with limit and offset",
+			status:   http.StatusOK,
+			path:     "/?limit=10&offset=20",
+			response: `[]`,
+			limit:    "10",
+			offset:   "20",
+			mockTodosSearch: todostest.MockSearch(
+				[]todos.Todo{},
+				todos.Filter{Limit: 10, Offset: 20},
+				nil,
+			),
+		},
+		{
+			name:     "limit is clamped",
+			status:   http.StatusOK,
+			path:     "/?limit=100000&offset=-1",
+			response: `[]`,
+			limit:    "1000",
+			offset:   "0",
+			mockTodosSearch: todostest.MockSearch(
+				[]todos.Todo{},
+				todos.Filter{Limit: 1000},
+				nil,
+			),
+		},
+		{
+			name:     "with view",
+			status:   http.StatusOK,
+			path:     "/?view=1&sort=title",
+			response: `[{"title":"Wake", "completed":true}]`,
+			limit:    "100",
+			offset:   "0",
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(views.View{ID: 1, Name: "Done", Completed: &trueb, Sort: "-updated_at", Fields: "title,completed"})
+			},
 			mockTodosSearch: todostest.MockSearch(
 				[]todos.Todo{{ID: 2, Title: "Wake", Completed: true}},
-				todos.Filter{Keyword: "Wake", Completed: &trueb},
+				todos.Filter{Completed: &trueb, Sort: "title", Limit: 100},
 				nil,
 			),
 		},
+		{
+			name:     "view not found",
+			status:   http.StatusNotFound,
+			path:     "/?view=1",
+			response: `{"error":"entity not found"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).NotFound()
+			},
+		},
+		{
+			name:     "invalid fields",
+			status:   http.StatusUnprocessableEntity,
+			path:     "/?fields=secret",
+			response: `{"error":"Field is invalid"}`,
+		},
+		{
+			name:     "invalid sort",
+			status:   http.StatusUnprocessableEntity,
+			path:     "/?sort=secret",
+			response: `{"error":"Sort is invalid"}`,
+			mockTodosSearch: todostest.MockSearch(
+				nil,
+				todos.Filter{Sort: "secret", Limit: 100},
+				todos.ErrFilterSortInvalid,
+			),
+		},
 	}
 
 	for _, test := range tests {
@@ -57,15 +134,21 @@ func TestTodos_Index(t *testing.T) {
 				rr         = httptest.NewRecorder()
 				repository = reltest.New()
 				todos      = &todostest.Service{}
-				handler    = handler.NewTodos(repository, todos)
+				handler    = handler.NewTodos(repository, todos, handler.DefaultPagination)
 			)
 
+			if test.mockRepo != nil {
+				test.mockRepo(repository)
+			}
+
 			todostest.Mock(todos, test.mockTodosSearch)
 
 			handler.ServeHTTP(rr, req)
 
 			assert.Equal(t, test.status, rr.Code)
 			assert.JSONEq(t, test.response, rr.Body.String())
+			assert.Equal(t, test.limit, rr.Header().Get("X-Pagination-Limit"))
+			assert.Equal(t, test.offset, rr.Header().Get("X-Pagination-Offset"))
 
 			repository.AssertExpectations(t)
 			todos.AssertExpectations(t)
@@ -91,7 +174,7 @@ func TestTodos_Create(t *testing.T) {
 			response: `{"id":1, "title":"Sleep", "completed":false, "order":0, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
 			location: "/1",
 			mockTodosCreate: todostest.MockCreate(
-				todos.Todo{ID: 1, Title: "Sleep"},
+				factories.Todo(func(todo *todos.Todo) { todo.ID = 1 }),
 				nil,
 			),
 		},
@@ -102,7 +185,7 @@ func TestTodos_Create(t *testing.T) {
 			payload:  `{"title": ""}`,
 			response: `{"error":"Title can't be blank"}`,
 			mockTodosCreate: todostest.MockCreate(
-				todos.Todo{Title: "Sleep"},
+				factories.Todo(),
 				todos.ErrTodoTitleBlank,
 			),
 		},
@@ -123,7 +206,7 @@ func TestTodos_Create(t *testing.T) {
 				rr         = httptest.NewRecorder()
 				repository = reltest.New()
 				todos      = &todostest.Service{}
-				handler    = handler.NewTodos(repository, todos)
+				handler    = handler.NewTodos(repository, todos, handler.DefaultPagination)
 			)
 
 			todostest.Mock(todos, test.mockTodosCreate)
@@ -155,7 +238,7 @@ func TestTodos_Show(t *testing.T) {
 			path:     "/1",
 			response: `{"id":1, "title":"Sleep", "completed":false, "order":0, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).Result(todos.Todo{ID: 1, Title: "Sleep"})
+				repo.ExpectFind(where.Eq("id", 1)).Result(factories.Todo(func(todo *todos.Todo) { todo.ID = 1 }))
 			},
 		},
 		{
@@ -184,7 +267,7 @@ func TestTodos_Show(t *testing.T) {
 				rr         = httptest.NewRecorder()
 				repository = reltest.New()
 				todos      = &todostest.Service{}
-				handler    = handler.NewTodos(repository, todos)
+				handler    = handler.NewTodos(repository, todos, handler.DefaultPagination)
 			)
 
 			if test.mockRepo != nil {
@@ -224,7 +307,7 @@ func TestTodos_Update(t *testing.T) {
 			payload:  `{"title": "Wake"}`,
 			response: `{"id":1, "title":"Wake", "completed":false, "order":0, "url":"todos/1", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).Result(todos.Todo{ID: 1, Title: "Sleep"})
+				repo.ExpectFind(where.Eq("id", 1)).Result(factories.Todo(func(todo *todos.Todo) { todo.ID = 1 }))
 			},
 			mockTodosUpdate: todostest.MockUpdate(
 				todos.Todo{ID: 1, Title: "Wake"},
@@ -238,7 +321,7 @@ func TestTodos_Update(t *testing.T) {
 			payload:  `{"title": ""}`,
 			response: `{"error":"Title can't be blank"}`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).Result(todos.Todo{ID: 1, Title: "Sleep"})
+				repo.ExpectFind(where.Eq("id", 1)).Result(factories.Todo(func(todo *todos.Todo) { todo.ID = 1 }))
 			},
 			mockTodosUpdate: todostest.MockUpdate(
 				todos.Todo{ID: 1, Title: ""},
@@ -252,7 +335,7 @@ func TestTodos_Update(t *testing.T) {
 			payload:  ``,
 			response: `{"error":"Bad Request"}`,
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).Result(todos.Todo{ID: 1, Title: "Sleep"})
+				repo.ExpectFind(where.Eq("id", 1)).Result(factories.Todo(func(todo *todos.Todo) { todo.ID = 1 }))
 			},
 		},
 	}
@@ -265,7 +348,7 @@ func TestTodos_Update(t *testing.T) {
 				rr         = httptest.NewRecorder()
 				repository = reltest.New()
 				todos      = &todostest.Service{}
-				handler    = handler.NewTodos(repository, todos)
+				handler    = handler.NewTodos(repository, todos, handler.DefaultPagination)
 			)
 
 			if test.mockRepo != nil {
@@ -300,7 +383,7 @@ func TestTodos_Destroy(t *testing.T) {
 			path:     "/1",
 			response: "",
 			mockRepo: func(repo *reltest.Repository) {
-				repo.ExpectFind(where.Eq("id", 1)).Result(todos.Todo{ID: 1, Title: "Sleep"})
+				repo.ExpectFind(where.Eq("id", 1)).Result(factories.Todo(func(todo *todos.Todo) { todo.ID = 1 }))
 			},
 			mockTodosDelete: todostest.MockDelete(),
 		},
@@ -313,7 +396,7 @@ func TestTodos_Destroy(t *testing.T) {
 				rr         = httptest.NewRecorder()
 				repository = reltest.New()
 				todos      = &todostest.Service{}
-				handler    = handler.NewTodos(repository, todos)
+				handler    = handler.NewTodos(repository, todos, handler.DefaultPagination)
 			)
 
 			if test.mockRepo != nil {
@@ -357,7 +440,7 @@ func TestTodos_Clear(t *testing.T) {
 				rr         = httptest.NewRecorder()
 				repository = reltest.New()
 				todos      = &todostest.Service{}
-				handler    = handler.NewTodos(repository, todos)
+				handler    = handler.NewTodos(repository, todos, handler.DefaultPagination)
 			)
 
 			todostest.Mock(todos, test.mockTodosClear)
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Fs02/go-todo-backend/views"
+	"github.com/go-chi/chi"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+const (
+	viewKey ctx = 2
+)
+
+// Views for saved views endpoints.
+type Views struct {
+	*chi.Mux
+	repository rel.Repository
+	views      views.Service
+	limits     Limits
+}
+
+// Index handle GET /.
+func (v Views) Index(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx    = r.Context()
+		page   = v.limits.Page(r)
+		result []views.View
+	)
+
+	v.repository.MustFindAll(ctx, &result, rel.SortAsc("id"), rel.Limit(page.Limit), rel.Offset(page.Offset))
+	page.Header(w)
+	render(w, result, 200)
+}
+
+// Create handle POST /
+func (v Views) Create(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		view views.View
+	)
+
+	if !decode(w, r, &view) {
+		return
+	}
+
+	if err := v.views.Create(ctx, &view); err != nil {
+		render(w, err, 422)
+		return
+	}
+
+	w.Header().Set("Location", fmt.Sprint(r.RequestURI, "/", view.ID))
+	render(w, view, 201)
+}
+
+// Show handle GET /{ID}
+func (v Views) Show(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		view = ctx.Value(viewKey).(views.View)
+	)
+
+	render(w, view, 200)
+}
+
+// Update handle PATCH /{ID}
+func (v Views) Update(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx     = r.Context()
+		view    = ctx.Value(viewKey).(views.View)
+		changes = rel.NewChangeset(&view)
+	)
+
+	if !decode(w, r, &view) {
+		return
+	}
+
+	if err := v.views.Update(ctx, &view, changes); err != nil {
+		render(w, err, 422)
+		return
+	}
+
+	render(w, view, 200)
+}
+
+// Destroy handle DELETE /{ID}
+func (v Views) Destroy(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx  = r.Context()
+		view = ctx.Value(viewKey).(views.View)
+	)
+
+	v.views.Delete(ctx, &view)
+	render(w, nil, 204)
+}
+
+// Load is middleware that loads view to context.
+func (v Views) Load(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			ctx   = r.Context()
+			id, _ = strconv.Atoi(chi.URLParam(r, "ID"))
+			view  views.View
+		)
+
+		if err := v.repository.Find(ctx, &view, where.Eq("id", id)); err != nil {
+			if errors.Is(err, rel.ErrNotFound) {
+				render(w, err, 404)
+				return
+			}
+			panic(err)
+		}
+
+		ctx = context.WithValue(ctx, viewKey, view)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// NewViews handler.
+func NewViews(repository rel.Repository, views views.Service, pagination Pagination) Views {
+	h := Views{
+		Mux:        chi.NewMux(),
+		repository: repository,
+		views:      views,
+		limits:     pagination.For("views"),
+	}
+
+	h.Get("/", h.Index)
+	h.Post("/", h.Create)
+	h.With(h.Load).Get("/{ID}", h.Show)
+	h.With(h.Load).Patch("/{ID}", h.Update)
+	h.With(h.Load).Delete("/{ID}", h.Destroy)
+
+	return h
+}
@@ -0,0 +1,191 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api/handler"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/Fs02/go-todo-backend/views"
+	"github.com/Fs02/go-todo-backend/views/viewstest"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestViews_Index(t *testing.T) {
+	var (
+		req, _     = http.NewRequest("GET", "/", nil)
+		rr         = httptest.NewRecorder()
+		repository = reltest.New()
+		service    = &viewstest.Service{}
+		handler    = handler.NewViews(repository, service, handler.DefaultPagination)
+	)
+
+	repository.ExpectFindAll(rel.SortAsc("id"), rel.Limit(100), rel.Offset(0)).Result([]views.View{{ID: 1, Name: "Done", Sort: "title"}})
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `[{"id":1, "name":"Done", "keyword":"", "completed":null, "sort":"title", "fields":"", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}]`, rr.Body.String())
+
+	repository.AssertExpectations(t)
+	service.AssertExpectations(t)
+}
+
+func TestViews_Create(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		payload         string
+		response        string
+		location        string
+		mockViewsCreate func(views *viewstest.Service)
+	}{
+		{
+			name:     "created",
+			status:   http.StatusCreated,
+			payload:  `{"name": "Done", "fields": "title"}`,
+			response: `{"id":1, "name":"Done", "keyword":"", "completed":null, "sort":"", "fields":"title", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			location: "/1",
+			mockViewsCreate: viewstest.MockCreate(
+				views.View{ID: 1, Name: "Done", Fields: "title"},
+				nil,
+			),
+		},
+		{
+			name:     "validation error",
+			status:   http.StatusUnprocessableEntity,
+			payload:  `{"name": "Done", "sort": "secret"}`,
+			response: `{"error":"Sort is invalid"}`,
+			mockViewsCreate: viewstest.MockCreate(
+				views.View{Name: "Done", Sort: "secret"},
+				todos.ErrFilterSortInvalid,
+			),
+		},
+		{
+			name:     "bad request",
+			status:   http.StatusBadRequest,
+			payload:  ``,
+			response: `{"error":"Bad Request"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				body       = strings.NewReader(test.payload)
+				req, _     = http.NewRequest("POST", "/", body)
+				rr         = httptest.NewRecorder()
+				repository = reltest.New()
+				views      = &viewstest.Service{}
+				handler    = handler.NewViews(repository, views, handler.DefaultPagination)
+			)
+
+			viewstest.Mock(views, test.mockViewsCreate)
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.Equal(t, test.location, rr.Header().Get("Location"))
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			repository.AssertExpectations(t)
+			views.AssertExpectations(t)
+		})
+	}
+}
+
+func TestViews_Show(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		mockRepo func(repo *reltest.Repository)
+	}{
+		{
+			name:     "ok",
+			status:   http.StatusOK,
+			response: `{"id":1, "name":"Done", "keyword":"", "completed":null, "sort":"", "fields":"", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).Result(views.View{ID: 1, Name: "Done"})
+			},
+		},
+		{
+			name:     "not found",
+			status:   http.StatusNotFound,
+			response: `{"error":"entity not found"}`,
+			mockRepo: func(repo *reltest.Repository) {
+				repo.ExpectFind(where.Eq("id", 1)).NotFound()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				req, _     = http.NewRequest("GET", "/1", nil)
+				rr         = httptest.NewRecorder()
+				repository = reltest.New()
+				views      = &viewstest.Service{}
+				handler    = handler.NewViews(repository, views, handler.DefaultPagination)
+			)
+
+			test.mockRepo(repository)
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.JSONEq(t, test.response, rr.Body.String())
+
+			repository.AssertExpectations(t)
+			views.AssertExpectations(t)
+		})
+	}
+}
+
+func TestViews_Update(t *testing.T) {
+	var (
+		body       = strings.NewReader(`{"fields": "title"}`)
+		req, _     = http.NewRequest("PATCH", "/1", body)
+		rr         = httptest.NewRecorder()
+		repository = reltest.New()
+		service    = &viewstest.Service{}
+		handler    = handler.NewViews(repository, service, handler.DefaultPagination)
+	)
+
+	repository.ExpectFind(where.Eq("id", 1)).Result(views.View{ID: 1, Name: "Done"})
+	viewstest.Mock(service, viewstest.MockUpdate(views.View{ID: 1, Name: "Done", Fields: "title"}, nil))
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"id":1, "name":"Done", "keyword":"", "completed":null, "sort":"", "fields":"title", "created_at":"0001-01-01T00:00:00Z", "updated_at":"0001-01-01T00:00:00Z"}`, rr.Body.String())
+
+	repository.AssertExpectations(t)
+	service.AssertExpectations(t)
+}
+
+func TestViews_Destroy(t *testing.T) {
+	var (
+		req, _     = http.NewRequest("DELETE", "/1", nil)
+		rr         = httptest.NewRecorder()
+		repository = reltest.New()
+		service    = &viewstest.Service{}
+		handler    = handler.NewViews(repository, service, handler.DefaultPagination)
+	)
+
+	repository.ExpectFind(where.Eq("id", 1)).Result(views.View{ID: 1, Name: "Done"})
+	viewstest.Mock(service, viewstest.MockDelete())
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Equal(t, "", rr.Body.String())
+
+	repository.AssertExpectations(t)
+	service.AssertExpectations(t)
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// Admin is middleware that only allows request authenticated using admin bearer token.
+// All request will be rejected when token is empty.
+func Admin(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !authorized(r, token) {
+				render(w, struct {
+					Error string `json:"error"`
+				}{
+					Error: "Unauthorized",
+				}, http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authorized returns true when request is authenticated using the bearer token, always false when token is empty.
+func authorized(r *http.Request, token string) bool {
+	authorization := r.Header.Get("Authorization")
+	if token == "" || !strings.HasPrefix(authorization, "Bearer ") {
+		return false
+	}
+
+	bearer := strings.TrimPrefix(authorization, "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1
+}
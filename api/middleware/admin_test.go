@@ -0,0 +1,74 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+var ok = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestAdmin(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		status        int
+		response      string
+	}{
+		{
+			name:          "authorized",
+			token:         "secret",
+			authorization: "Bearer secret",
+			status:        http.StatusOK,
+		},
+		{
+			name:          "invalid token",
+			token:         "secret",
+			authorization: "Bearer invalid",
+			status:        http.StatusUnauthorized,
+			response:      `{"error":"Unauthorized"}`,
+		},
+		{
+			name:          "missing scheme",
+			token:         "secret",
+			authorization: "secret",
+			status:        http.StatusUnauthorized,
+			response:      `{"error":"Unauthorized"}`,
+		},
+		{
+			name:     "missing token",
+			token:    "secret",
+			status:   http.StatusUnauthorized,
+			response: `{"error":"Unauthorized"}`,
+		},
+		{
+			name:     "admin disabled",
+			token:    "",
+			status:   http.StatusUnauthorized,
+			response: `{"error":"Unauthorized"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				req, _ = http.NewRequest("GET", "/", nil)
+				rr     = httptest.NewRecorder()
+			)
+
+			req.Header.Set("Authorization", test.authorization)
+			middleware.Admin(test.token)(ok).ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			if test.response != "" {
+				assert.JSONEq(t, test.response, rr.Body.String())
+			}
+		})
+	}
+}
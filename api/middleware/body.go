@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+type bodyKey struct{}
+
+// MaxBodySize is middleware that limits request body size, reading larger body returns *http.MaxBytesError that should be rendered as 413 by the handler.
+// It can be applied again on specific route to override the global limit, the override can be larger than the global limit.
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// keep the original body, so the limit can be overridden by subsequent MaxBodySize.
+			body, ok := r.Context().Value(bodyKey{}).(io.ReadCloser)
+			if !ok {
+				body = r.Body
+				r = r.WithContext(context.WithValue(r.Context(), bodyKey{}, body))
+			}
+
+			r.Body = http.MaxBytesReader(w, body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
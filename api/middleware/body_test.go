@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	w.Write(body)
+})
+
+func TestMaxBodySize(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  http.Handler
+		body     string
+		status   int
+		response string
+	}{
+		{
+			name:     "within limit",
+			handler:  middleware.MaxBodySize(5)(echo),
+			body:     "lorem",
+			status:   http.StatusOK,
+			response: "lorem",
+		},
+		{
+			name:    "exceeds limit",
+			handler: middleware.MaxBodySize(4)(echo),
+			body:    "lorem",
+			status:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "override with larger limit",
+			handler:  middleware.MaxBodySize(4)(middleware.MaxBodySize(10)(echo)),
+			body:     "lorem",
+			status:   http.StatusOK,
+			response: "lorem",
+		},
+		{
+			name:    "override with smaller limit",
+			handler: middleware.MaxBodySize(10)(middleware.MaxBodySize(4)(echo)),
+			body:    "lorem",
+			status:  http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				req, _ = http.NewRequest("POST", "/", strings.NewReader(test.body))
+				rr     = httptest.NewRecorder()
+			)
+
+			test.handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.Equal(t, test.response, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package middleware
+
+import "go.uber.org/zap"
+
+// SetLogger replaces package logger, it returns a function that restores the original logger.
+func SetLogger(l *zap.Logger) func() {
+	original := logger
+	logger = l
+
+	return func() { logger = original }
+}
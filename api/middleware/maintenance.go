@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Fs02/go-todo-backend/maintenance"
+)
+
+// Maintenance is middleware that responds with 503 while maintenance is enabled.
+// While read only, only request that writes is rejected, so reads keep being served.
+func Maintenance(service maintenance.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := service.Status()
+
+			var code string
+			switch {
+			case status.Enabled:
+				code = "Service Unavailable"
+			case status.ReadOnly && !safe(r.Method):
+				code = "Read Only"
+			default:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Retry-After", strconv.Itoa(status.RetryAfter))
+			render(w, struct {
+				Error      string `json:"error"`
+				Message    string `json:"message"`
+				RetryAfter int    `json:"retry_after"`
+			}{
+				Error:      code,
+				Message:    status.Message,
+				RetryAfter: status.RetryAfter,
+			}, http.StatusServiceUnavailable)
+		})
+	}
+}
+
+// safe method doesn't modify any resource.
+func safe(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,72 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api/middleware"
+	"github.com/Fs02/go-todo-backend/maintenance"
+	"github.com/Fs02/go-todo-backend/maintenance/maintenancetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenance(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		status          int
+		retryAfter      string
+		response        string
+		mockMaintenance func(service *maintenancetest.Service)
+	}{
+		{
+			name:            "disabled",
+			status:          http.StatusOK,
+			mockMaintenance: maintenancetest.MockStatus(maintenance.Maintenance{}),
+		},
+		{
+			name:            "enabled",
+			status:          http.StatusServiceUnavailable,
+			retryAfter:      "60",
+			response:        `{"error":"Service Unavailable", "message":"Upgrading database", "retry_after":60}`,
+			mockMaintenance: maintenancetest.MockStatus(maintenance.Maintenance{Enabled: true, Message: "Upgrading database", RetryAfter: 60}),
+		},
+		{
+			name:            "read only",
+			method:          "GET",
+			status:          http.StatusOK,
+			mockMaintenance: maintenancetest.MockStatus(maintenance.Maintenance{ReadOnly: true}),
+		},
+		{
+			name:            "read only write",
+			method:          "POST",
+			status:          http.StatusServiceUnavailable,
+			retryAfter:      "60",
+			response:        `{"error":"Read Only", "message":"Failing over database", "retry_after":60}`,
+			mockMaintenance: maintenancetest.MockStatus(maintenance.Maintenance{ReadOnly: true, Message: "Failing over database", RetryAfter: 60}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				req, _  = http.NewRequest(test.method, "/", nil)
+				rr      = httptest.NewRecorder()
+				service = &maintenancetest.Service{}
+			)
+
+			maintenancetest.Mock(service, test.mockMaintenance)
+
+			middleware.Maintenance(service)(ok).ServeHTTP(rr, req)
+
+			assert.Equal(t, test.status, rr.Code)
+			assert.Equal(t, test.retryAfter, rr.Header().Get("Retry-After"))
+			if test.response != "" {
+				assert.JSONEq(t, test.response, rr.Body.String())
+			}
+
+			service.AssertExpectations(t)
+		})
+	}
+}
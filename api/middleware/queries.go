@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Fs02/go-todo-backend/diagnostics"
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+// ServerTimingHeader contains database stats of the request, only returned to admin.
+const ServerTimingHeader = "Server-Timing"
+
+// QueryBudget is middleware that counts database queries executed by the request and the time spent on it.
+// Request authenticated using admin token receives the stats in Server-Timing header,
+// and request that executes more queries than budget is logged along with its route, budget is disabled when zero.
+func QueryBudget(adminToken string, budget int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, stats := diagnostics.Track(r.Context())
+
+			if authorized(r, adminToken) {
+				w = &timingWriter{ResponseWriter: w, stats: stats}
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+
+			if queries := stats.Queries(); budget > 0 && queries > budget {
+				route := r.URL.Path
+				if rctx := chi.RouteContext(ctx); rctx != nil {
+					route = rctx.RoutePattern()
+				}
+
+				logger.Warn("query budget exceeded",
+					zap.String("method", r.Method),
+					zap.String("route", route),
+					zap.Int("queries", queries),
+					zap.Int("budget", budget),
+					zap.Duration("duration", stats.Duration()))
+			}
+		})
+	}
+}
+
+// timingWriter writes Server-Timing header right before the response header is written.
+type timingWriter struct {
+	http.ResponseWriter
+	stats       *diagnostics.Stats
+	wroteHeader bool
+}
+
+func (w *timingWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.Header().Set(ServerTimingHeader, w.stats.ServerTiming())
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *timingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
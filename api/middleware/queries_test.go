@@ -0,0 +1,109 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api/middleware"
+	"github.com/Fs02/go-todo-backend/diagnostics"
+	"github.com/go-rel/postgres"
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// execAdapter executes statement without touching database.
+type execAdapter struct {
+	rel.Adapter
+}
+
+func (a execAdapter) Exec(ctx context.Context, stmt string, args []any) (int64, int64, error) {
+	return 0, 0, nil
+}
+
+func TestQueryBudget(t *testing.T) {
+	var (
+		wrapped = diagnostics.Wrap(postgres.New(nil), diagnostics.New(nil, 0)).(diagnostics.Adapter)
+		queries = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wrapped.Exec(r.Context(), "SELECT 1;", nil)
+			wrapped.Exec(r.Context(), "SELECT 2;", nil)
+			w.Write([]byte("ok"))
+		})
+	)
+
+	wrapped.Adapter = execAdapter{}
+
+	tests := []struct {
+		name     string
+		token    string
+		budget   int
+		timing   bool
+		exceeded bool
+	}{
+		{
+			name:   "admin",
+			token:  "secret",
+			budget: 0,
+			timing: true,
+		},
+		{
+			name:     "admin exceeds budget",
+			token:    "secret",
+			budget:   1,
+			timing:   true,
+			exceeded: true,
+		},
+		{
+			name:   "within budget",
+			token:  "secret",
+			budget: 2,
+			timing: true,
+		},
+		{
+			name:     "not admin",
+			token:    "invalid",
+			budget:   1,
+			exceeded: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				req, _     = http.NewRequest("GET", "/", nil)
+				rr         = httptest.NewRecorder()
+				core, logs = observer.New(zap.WarnLevel)
+			)
+
+			defer middleware.SetLogger(zap.New(core))()
+
+			req.Header.Set("Authorization", "Bearer "+test.token)
+			middleware.QueryBudget("secret", test.budget)(queries).ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, "ok", rr.Body.String())
+
+			if test.timing {
+				assert.Regexp(t, `^db;dur=\d+\.\d{2};desc="2 queries"$`, rr.Header().Get(middleware.ServerTimingHeader))
+			} else {
+				assert.Empty(t, rr.Header().Get(middleware.ServerTimingHeader))
+			}
+
+			if test.exceeded {
+				exceeded := logs.FilterMessage("query budget exceeded").All()
+				assert.Len(t, exceeded, 1)
+				assert.Equal(t, map[string]interface{}{"method": "GET", "route": "/", "queries": int64(2), "budget": int64(test.budget)}, withoutDuration(exceeded[0].ContextMap()))
+			} else {
+				assert.Zero(t, logs.Len())
+			}
+		})
+	}
+}
+
+func withoutDuration(fields map[string]interface{}) map[string]interface{} {
+	delete(fields, "duration")
+	return fields
+}
@@ -0,0 +1,180 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "backup")))
+)
+
+// Format version of the archive, increased whenever archive layout changes.
+// Format 2 lists the backed up tables in the header.
+const Format = 2
+
+// Manifest describes content of an archive.
+// It's written at the beginning of archive with the backed up tables without number of rows, and at the end of archive along with number of rows of every table.
+type Manifest struct {
+	Format        int             `json:"format"`
+	SchemaVersion int             `json:"schema_version"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Tables        []TableManifest `json:"tables,omitempty"`
+}
+
+// TableManifest describes backed up table.
+type TableManifest struct {
+	Name string `json:"name"`
+	Rows int    `json:"rows"`
+}
+
+// Options for backup and restore.
+type Options struct {
+	// Tables to backup or restore, every registered table is backed up and every table in the archive is restored when empty.
+	Tables []string
+	// BatchSize of rows loaded from database when backing up, default to 1000.
+	BatchSize int
+	// Replace existing rows when restoring, otherwise restore fails when table is not empty.
+	Replace bool
+	// Force restoring archive created from different schema version.
+	Force bool
+}
+
+// record is a single line of archive.
+type record struct {
+	Header   *Manifest       `json:"header,omitempty"`
+	Table    string          `json:"table,omitempty"`
+	Row      json.RawMessage `json:"row,omitempty"`
+	Manifest *Manifest       `json:"manifest,omitempty"`
+}
+
+// schemaVersion returns the latest migration applied to database.
+func schemaVersion(ctx context.Context, repository rel.Repository) (int, error) {
+	return repository.Aggregate(ctx, rel.From("rel_schema_versions"), "max", "version")
+}
+
+// Backup selected tables through repository into a compressed archive encrypted using key.
+// Tables are read inside a single repeatable read transaction, so the archive is consistent across tables.
+func Backup(ctx context.Context, repository rel.Repository, w io.Writer, key []byte, options Options) (Manifest, error) {
+	var (
+		manifest = Manifest{Format: Format, CreatedAt: time.Now().UTC()}
+	)
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = 1000
+	}
+
+	tables, err := selectTables(options.Tables)
+	if err != nil {
+		return manifest, err
+	}
+
+	encrypter, err := newEncrypter(w, key)
+	if err != nil {
+		return manifest, err
+	}
+
+	var (
+		compressor = gzip.NewWriter(encrypter)
+		encoder    = json.NewEncoder(compressor)
+	)
+
+	err = repository.Transaction(ctx, func(ctx context.Context) error {
+		if _, _, err := repository.Exec(ctx, "SET TRANSACTION ISOLATION LEVEL REPEATABLE READ;"); err != nil {
+			return err
+		}
+
+		version, err := schemaVersion(ctx, repository)
+		if err != nil {
+			return err
+		}
+
+		manifest.SchemaVersion = version
+
+		header := manifest
+		for _, table := range tables {
+			header.Tables = append(header.Tables, TableManifest{Name: table.Name})
+		}
+
+		if err := encoder.Encode(record{Header: &header}); err != nil {
+			return err
+		}
+
+		for _, table := range tables {
+			rows, err := dump(ctx, repository, encoder, table, options.BatchSize)
+			if err != nil {
+				return err
+			}
+
+			logger.Info("table backed up", zap.String("table", table.Name), zap.Int("rows", rows))
+			manifest.Tables = append(manifest.Tables, TableManifest{Name: table.Name, Rows: rows})
+		}
+
+		return encoder.Encode(record{Manifest: &manifest})
+	})
+
+	if err != nil {
+		return manifest, err
+	}
+
+	if err := compressor.Close(); err != nil {
+		return manifest, err
+	}
+
+	return manifest, encrypter.Close()
+}
+
+// dump rows of the table in batches, batches are paginated by the last id so every batch uses the primary key index.
+func dump(ctx context.Context, repository rel.Repository, encoder *json.Encoder, table Table, batchSize int) (int, error) {
+	var (
+		rows    int
+		queries = []rel.Querier{rel.SortAsc("id"), rel.Limit(batchSize)}
+	)
+
+	for {
+		var (
+			slice = table.slice()
+		)
+
+		if err := repository.FindAll(ctx, slice.Interface(), queries...); err != nil {
+			return rows, err
+		}
+
+		collection := rel.NewCollection(slice.Interface(), true)
+		for i := 0; i < collection.Len(); i++ {
+			var (
+				doc = collection.Get(i)
+				row = make(map[string]interface{}, len(doc.Fields()))
+			)
+
+			// rows are encoded by its column name instead of json field, so every column is included.
+			for _, field := range doc.Fields() {
+				row[field], _ = doc.Value(field)
+			}
+
+			encoded, err := json.Marshal(row)
+			if err != nil {
+				return rows, err
+			}
+
+			if err := encoder.Encode(record{Table: table.Name, Row: encoded}); err != nil {
+				return rows, err
+			}
+		}
+
+		rows += collection.Len()
+		if collection.Len() < batchSize {
+			return rows, nil
+		}
+
+		lastID, _ := collection.Get(collection.Len() - 1).Value("id")
+		queries = []rel.Querier{where.Gt("id", lastID), rel.SortAsc("id"), rel.Limit(batchSize)}
+	}
+}
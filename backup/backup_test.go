@@ -0,0 +1,92 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	version = 20261710100000
+	setval  = `SELECT setval(pg_get_serial_sequence('todos', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM "todos";`
+)
+
+var (
+	// reltest passes exec args as a single slice argument.
+	noArgs []interface{}
+	rows   = []todos.Todo{
+		{ID: 1, Title: "Wake up", Completed: true, CreatedAt: time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Make coffee", Order: 1, CreatedAt: time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC)},
+		{ID: 5, Title: "Sleep", Order: 2, CreatedAt: time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2026, 10, 17, 7, 0, 0, 0, time.UTC)},
+	}
+)
+
+func backup(t *testing.T, key []byte) []byte {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		archive    bytes.Buffer
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectExec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ;", noArgs).Result(0, 0)
+		repository.ExpectAggregate(rel.From("rel_schema_versions"), "max", "version").Result(version)
+		repository.ExpectFindAll(rel.SortAsc("id"), rel.Limit(2)).Result(rows[:2])
+		repository.ExpectFindAll(where.Gt("id", uint(2)), rel.SortAsc("id"), rel.Limit(2)).Result(rows[2:])
+	})
+
+	manifest, err := Backup(ctx, repository, &archive, key, Options{Tables: []string{"todos"}, BatchSize: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, version, manifest.SchemaVersion)
+	assert.Equal(t, []TableManifest{{Name: "todos", Rows: 3}}, manifest.Tables)
+
+	repository.AssertExpectations(t)
+	return archive.Bytes()
+}
+
+func TestBackupRestore(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	rand.Read(key)
+	archive := backup(t, key)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectAggregate(rel.From("rel_schema_versions"), "max", "version").Result(version)
+		repository.ExpectCount("todos").Result(0)
+		for i := range rows {
+			repository.ExpectInsert(verbatim{}).For(&rows[i])
+		}
+		repository.ExpectExec(setval, noArgs).Result(0, 0)
+	})
+
+	manifest, err := Restore(ctx, repository, bytes.NewReader(archive), key, Options{Tables: []string{"todos"}})
+	assert.Nil(t, err)
+	assert.Equal(t, version, manifest.SchemaVersion)
+
+	repository.AssertExpectations(t)
+}
+
+func TestBackup_unknownTable(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	_, err := Backup(ctx, repository, &bytes.Buffer{}, key, Options{Tables: []string{"users"}})
+	assert.Equal(t, UnknownTableError("users"), err)
+
+	repository.AssertExpectations(t)
+}
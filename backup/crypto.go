@@ -0,0 +1,224 @@
+package backup
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	chunkSize = 64 << 10
+	finalFlag = 1 << 31
+)
+
+var (
+	magic = []byte("BTBK\x01")
+	// ErrInvalidKey returned when key is not a base64 encoded 32 bytes key.
+	ErrInvalidKey = errors.New("backup: key must be 32 bytes encoded in base64")
+	// ErrCorrupted returned when archive is truncated, tampered or encrypted using different key.
+	ErrCorrupted = errors.New("backup: archive is corrupted or encrypted using different key")
+)
+
+// ParseKey decodes base64 encoded AES-256 key, generate one using: openssl rand -base64 32
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return nil, ErrInvalidKey
+	}
+
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, ErrInvalidKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// nonce of a chunk, the counter and final flag prevents chunks from being reordered or truncated.
+func nonce(prefix []byte, counter uint32, final bool) []byte {
+	n := make([]byte, 12)
+	copy(n, prefix)
+	binary.BigEndian.PutUint32(n[7:], counter)
+	if final {
+		n[11] = 1
+	}
+
+	return n
+}
+
+// encrypter seals written data in chunks using AES-GCM, so archive of any size can be streamed.
+type encrypter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+}
+
+func (e *encrypter) Write(p []byte) (int, error) {
+	n := len(p)
+
+	for len(p) > 0 {
+		free := chunkSize - len(e.buf)
+		if free == 0 {
+			if err := e.seal(false); err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		if free > len(p) {
+			free = len(p)
+		}
+
+		e.buf = append(e.buf, p[:free]...)
+		p = p[free:]
+	}
+
+	return n, nil
+}
+
+// Close seals the remaining data as the final chunk.
+func (e *encrypter) Close() error {
+	return e.seal(true)
+}
+
+func (e *encrypter) seal(final bool) error {
+	var (
+		sealed = e.aead.Seal(nil, nonce(e.prefix, e.counter, final), e.buf, nil)
+		header = uint32(len(sealed))
+		lenbuf = make([]byte, 4)
+	)
+
+	if final {
+		header |= finalFlag
+	}
+
+	binary.BigEndian.PutUint32(lenbuf, header)
+	if _, err := e.w.Write(append(lenbuf, sealed...)); err != nil {
+		return err
+	}
+
+	e.counter++
+	e.buf = e.buf[:0]
+	return nil
+}
+
+func newEncrypter(w io.Writer, key []byte) (*encrypter, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &encrypter{
+		w:      w,
+		aead:   aead,
+		prefix: make([]byte, 7),
+		buf:    make([]byte, 0, chunkSize),
+	}
+
+	if _, err := rand.Read(e.prefix); err != nil {
+		return nil, err
+	}
+
+	if _, err := w.Write(append(append([]byte{}, magic...), e.prefix...)); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// decrypter opens chunks written by encrypter.
+type decrypter struct {
+	r       io.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	final   bool
+}
+
+func (d *decrypter) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decrypter) open() error {
+	lenbuf := make([]byte, 4)
+	if _, err := io.ReadFull(d.r, lenbuf); err != nil {
+		return ErrCorrupted
+	}
+
+	var (
+		header = binary.BigEndian.Uint32(lenbuf)
+		final  = header&finalFlag != 0
+		size   = int(header &^ finalFlag)
+	)
+
+	if size > chunkSize+d.aead.Overhead() {
+		return ErrCorrupted
+	}
+
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return ErrCorrupted
+	}
+
+	opened, err := d.aead.Open(nil, nonce(d.prefix, d.counter, final), sealed, nil)
+	if err != nil {
+		return ErrCorrupted
+	}
+
+	// nothing should be written after the final chunk.
+	if final {
+		if n, _ := d.r.Read(make([]byte, 1)); n > 0 {
+			return ErrCorrupted
+		}
+	}
+
+	d.counter++
+	d.buf = opened
+	d.final = final
+	return nil
+}
+
+func newDecrypter(r io.Reader, key []byte) (*decrypter, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, len(magic)+7)
+	if _, err := io.ReadFull(r, header); err != nil || string(header[:len(magic)]) != string(magic) {
+		return nil, ErrCorrupted
+	}
+
+	return &decrypter{
+		r:      r,
+		aead:   aead,
+		prefix: header[len(magic):],
+	}, nil
+}
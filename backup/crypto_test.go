@@ -0,0 +1,109 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encrypt(t *testing.T, key []byte, plain []byte) []byte {
+	var (
+		buf          bytes.Buffer
+		encrypter, _ = newEncrypter(&buf, key)
+	)
+
+	_, err := encrypter.Write(plain)
+	assert.Nil(t, err)
+	assert.Nil(t, encrypter.Close())
+
+	return buf.Bytes()
+}
+
+func decrypt(key []byte, sealed []byte) ([]byte, error) {
+	decrypter, err := newDecrypter(bytes.NewReader(sealed), key)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.ReadAll(decrypter)
+}
+
+func TestEncryption(t *testing.T) {
+	var (
+		key = make([]byte, 32)
+	)
+
+	rand.Read(key)
+
+	for _, size := range []int{0, 10, chunkSize, 2*chunkSize + 10} {
+		plain := make([]byte, size)
+		rand.Read(plain)
+
+		sealed := encrypt(t, key, plain)
+		opened, err := decrypt(key, sealed)
+
+		assert.Nil(t, err)
+		assert.Equal(t, plain, opened)
+	}
+}
+
+func TestEncryption_corrupted(t *testing.T) {
+	var (
+		key   = make([]byte, 32)
+		other = make([]byte, 32)
+		plain = make([]byte, 2*chunkSize)
+	)
+
+	rand.Read(key)
+	rand.Read(other)
+	rand.Read(plain)
+
+	sealed := encrypt(t, key, plain)
+
+	t.Run("different key", func(t *testing.T) {
+		_, err := decrypt(other, sealed)
+		assert.Equal(t, ErrCorrupted, err)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := decrypt(key, sealed[:len(sealed)-chunkSize])
+		assert.Equal(t, ErrCorrupted, err)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := append([]byte{}, sealed...)
+		tampered[len(tampered)-1] ^= 1
+
+		_, err := decrypt(key, tampered)
+		assert.Equal(t, ErrCorrupted, err)
+	})
+
+	t.Run("appended", func(t *testing.T) {
+		_, err := decrypt(key, append(append([]byte{}, sealed...), 0))
+		assert.Equal(t, ErrCorrupted, err)
+	})
+
+	t.Run("not an archive", func(t *testing.T) {
+		_, err := decrypt(key, []byte("plain text"))
+		assert.Equal(t, ErrCorrupted, err)
+	})
+}
+
+func TestParseKey(t *testing.T) {
+	key := make([]byte, 32)
+	rand.Read(key)
+
+	parsed, err := ParseKey(base64.StdEncoding.EncodeToString(key))
+	assert.Nil(t, err)
+	assert.Equal(t, key, parsed)
+
+	_, err = ParseKey(base64.StdEncoding.EncodeToString(key[:16]))
+	assert.Equal(t, ErrInvalidKey, err)
+
+	_, err = ParseKey("not base64")
+	assert.Equal(t, ErrInvalidKey, err)
+}
@@ -0,0 +1,248 @@
+package backup
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrFormatUnsupported returned when archive is created by newer version.
+	ErrFormatUnsupported = errors.New("backup: archive format is not supported")
+	// ErrSchemaMismatch returned when archive is created from different schema version.
+	ErrSchemaMismatch = errors.New("backup: archive is created from different schema version")
+	// ErrTableNotEmpty returned when restoring to non empty table without replace.
+	ErrTableNotEmpty = errors.New("backup: table is not empty")
+	// ErrTablesRequired returned when restoring archive that doesn't list its tables (format 1) without selecting tables.
+	ErrTablesRequired = errors.New("backup: archive doesn't list its tables, select tables to restore")
+)
+
+// verbatim mutator inserts every field as is, including primary key and timestamps.
+type verbatim struct{}
+
+func (verbatim) Apply(doc *rel.Document, mutation *rel.Mutation) {
+	for _, field := range doc.Fields() {
+		value, _ := doc.Value(field)
+		mutation.Add(rel.Set(field, value))
+	}
+}
+
+// Restore selected tables from archive encrypted using key.
+// Restore runs inside a single transaction, nothing is restored when the archive turns out to be incomplete or corrupted.
+func Restore(ctx context.Context, repository rel.Repository, r io.Reader, key []byte, options Options) (Manifest, error) {
+	var (
+		header Manifest
+	)
+
+	// unknown table is rejected before reading the archive.
+	if _, err := selectTables(options.Tables); err != nil {
+		return header, err
+	}
+
+	decrypter, err := newDecrypter(r, key)
+	if err != nil {
+		return header, err
+	}
+
+	decompressor, err := gzip.NewReader(decrypter)
+	if err != nil {
+		return header, ErrCorrupted
+	}
+
+	var (
+		decoder = json.NewDecoder(decompressor)
+		first   record
+	)
+
+	if err := decoder.Decode(&first); err != nil || first.Header == nil {
+		return header, ErrCorrupted
+	}
+
+	header = *first.Header
+	if header.Format > Format {
+		return header, ErrFormatUnsupported
+	}
+
+	tables, err := restoreTables(header, options.Tables)
+	if err != nil {
+		return header, err
+	}
+
+	err = repository.Transaction(ctx, func(ctx context.Context) error {
+		if !options.Force {
+			version, err := schemaVersion(ctx, repository)
+			if err != nil {
+				return err
+			}
+
+			if version != header.SchemaVersion {
+				return ErrSchemaMismatch
+			}
+		}
+
+		if err := prepare(ctx, repository, tables, options.Replace); err != nil {
+			return err
+		}
+
+		restored, err := load(ctx, repository, decoder, tables)
+		if err != nil {
+			return err
+		}
+
+		for _, table := range tables {
+			logger.Info("table restored", zap.String("table", table.Name), zap.Int("rows", restored[table.Name]))
+
+			// continue generating id after the last restored id.
+			stmt := fmt.Sprintf(`SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM "%s";`, table.Name, table.Name)
+			if _, _, err := repository.Exec(ctx, stmt); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return header, err
+}
+
+// restoreTables selects tables in the archive, so replacing never deletes rows of a table that isn't restored.
+// Every table in the archive is selected when names is empty.
+func restoreTables(header Manifest, names []string) ([]Table, error) {
+	// format 1 archive doesn't list its tables.
+	if len(header.Tables) == 0 {
+		if len(names) == 0 {
+			return nil, ErrTablesRequired
+		}
+
+		return selectTables(names)
+	}
+
+	archived := make(map[string]bool, len(header.Tables))
+	for _, table := range header.Tables {
+		archived[table.Name] = true
+	}
+
+	if len(names) == 0 {
+		for _, table := range header.Tables {
+			names = append(names, table.Name)
+		}
+	}
+
+	for _, name := range names {
+		if !archived[name] {
+			return nil, MissingTableError(name)
+		}
+	}
+
+	return selectTables(names)
+}
+
+// prepare tables before restoring, existing rows are deleted in the reverse order so referencing rows are deleted first.
+func prepare(ctx context.Context, repository rel.Repository, tables []Table, replace bool) error {
+	for i := len(tables) - 1; i >= 0; i-- {
+		if replace {
+			if _, err := repository.DeleteAny(ctx, rel.From(tables[i].Name)); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		count, err := repository.Count(ctx, tables[i].Name)
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return fmt.Errorf("%w: %s", ErrTableNotEmpty, tables[i].Name)
+		}
+	}
+
+	return nil
+}
+
+// load inserts rows of selected tables, and verifies number of rows against the manifest at the end of archive.
+func load(ctx context.Context, repository rel.Repository, decoder *json.Decoder, tables []Table) (map[string]int, error) {
+	var (
+		selected = make(map[string]Table, len(tables))
+		restored = make(map[string]int, len(tables))
+		read     = make(map[string]int)
+	)
+
+	for _, table := range tables {
+		selected[table.Name] = table
+	}
+
+	for {
+		var rec record
+		if err := decoder.Decode(&rec); err != nil {
+			// archive ended before manifest.
+			return nil, ErrCorrupted
+		}
+
+		if rec.Manifest != nil {
+			for _, table := range rec.Manifest.Tables {
+				if read[table.Name] != table.Rows {
+					return nil, ErrCorrupted
+				}
+			}
+
+			return restored, nil
+		}
+
+		read[rec.Table]++
+
+		table, ok := selected[rec.Table]
+		if !ok {
+			continue
+		}
+
+		entity, err := decode(table, rec.Row)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := repository.Insert(ctx, entity, verbatim{}); err != nil {
+			return nil, err
+		}
+
+		restored[rec.Table]++
+	}
+}
+
+// decode row encoded by column name into a new entity of the table.
+func decode(table Table, row json.RawMessage) (interface{}, error) {
+	var (
+		columns map[string]json.RawMessage
+		entity  = table.entity()
+		doc     = rel.NewDocument(entity.Interface())
+	)
+
+	if err := json.Unmarshal(row, &columns); err != nil {
+		return nil, ErrCorrupted
+	}
+
+	for _, field := range doc.Fields() {
+		raw, ok := columns[field]
+		if !ok || string(raw) == "null" {
+			continue
+		}
+
+		typ, _ := doc.Type(field)
+		value := reflect.New(typ)
+		if err := json.Unmarshal(raw, value.Interface()); err != nil {
+			return nil, ErrCorrupted
+		}
+
+		doc.SetValue(field, value.Elem().Interface())
+	}
+
+	return entity.Interface(), nil
+}
@@ -0,0 +1,280 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+// encodeArchive writes records as an encrypted archive, used to build archive that can't be produced by Backup.
+func encodeArchive(t *testing.T, key []byte, records ...record) []byte {
+	var (
+		buffer bytes.Buffer
+	)
+
+	encrypter, err := newEncrypter(&buffer, key)
+	assert.Nil(t, err)
+
+	var (
+		compressor = gzip.NewWriter(encrypter)
+		encoder    = json.NewEncoder(compressor)
+	)
+
+	for _, rec := range records {
+		assert.Nil(t, encoder.Encode(rec))
+	}
+
+	assert.Nil(t, compressor.Close())
+	assert.Nil(t, encrypter.Close())
+	return buffer.Bytes()
+}
+
+func row(t *testing.T, table string, index int) record {
+	encoded, err := json.Marshal(map[string]interface{}{
+		"id":         rows[index].ID,
+		"title":      rows[index].Title,
+		"completed":  rows[index].Completed,
+		"order":      rows[index].Order,
+		"created_at": rows[index].CreatedAt,
+		"updated_at": rows[index].UpdatedAt,
+	})
+	assert.Nil(t, err)
+
+	return record{Table: table, Row: encoded}
+}
+
+func TestRestore_replace(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	rand.Read(key)
+	archive := backup(t, key)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectDeleteAny(rel.From("todos")).Unsafe()
+		for i := range rows {
+			repository.ExpectInsert(verbatim{}).For(&rows[i])
+		}
+		repository.ExpectExec(setval, noArgs).Result(0, 0)
+	})
+
+	_, err := Restore(ctx, repository, bytes.NewReader(archive), key, Options{Tables: []string{"todos"}, Replace: true, Force: true})
+	assert.Nil(t, err)
+
+	repository.AssertExpectations(t)
+}
+
+func TestRestore_replaceError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	rand.Read(key)
+	archive := backup(t, key)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectDeleteAny(rel.From("todos")).Unsafe().ConnectionClosed()
+	})
+
+	_, err := Restore(ctx, repository, bytes.NewReader(archive), key, Options{Tables: []string{"todos"}, Replace: true, Force: true})
+	assert.Equal(t, reltest.ErrConnectionClosed, err)
+
+	repository.AssertExpectations(t)
+}
+
+func TestRestore_setvalError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	rand.Read(key)
+	archive := backup(t, key)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectCount("todos").Result(0)
+		repository.ExpectInsert(verbatim{}).ForType("todos.Todo").Success().Times(3)
+		repository.ExpectExec(setval, noArgs).ConnectionClosed()
+	})
+
+	_, err := Restore(ctx, repository, bytes.NewReader(archive), key, Options{Tables: []string{"todos"}, Force: true})
+	assert.Equal(t, reltest.ErrConnectionClosed, err)
+
+	repository.AssertExpectations(t)
+}
+
+func TestRestore_rowsMismatch(t *testing.T) {
+	var (
+		ctx      = context.TODO()
+		key      = make([]byte, 32)
+		header   = Manifest{Format: Format, SchemaVersion: version}
+		manifest = Manifest{Format: Format, SchemaVersion: version, Tables: []TableManifest{{Name: "todos", Rows: 2}, {Name: "points", Rows: 1}}}
+	)
+
+	rand.Read(key)
+
+	tests := []struct {
+		name    string
+		records []record
+		inserts int
+	}{
+		{
+			name:    "missing selected rows",
+			records: []record{{Header: &header}, row(t, "todos", 0), {Manifest: &manifest}},
+			inserts: 1,
+		},
+		{
+			name:    "missing other table rows",
+			records: []record{{Header: &header}, row(t, "todos", 0), row(t, "todos", 1), {Manifest: &manifest}},
+			inserts: 2,
+		},
+		{
+			name:    "missing manifest",
+			records: []record{{Header: &header}, row(t, "todos", 0)},
+			inserts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repository := reltest.New()
+			repository.ExpectTransaction(func(repository *reltest.Repository) {
+				repository.ExpectAggregate(rel.From("rel_schema_versions"), "max", "version").Result(version)
+				repository.ExpectCount("todos").Result(0)
+				repository.ExpectInsert(verbatim{}).ForType("todos.Todo").Success().Times(test.inserts)
+			})
+
+			_, err := Restore(ctx, repository, bytes.NewReader(encodeArchive(t, key, test.records...)), key, Options{Tables: []string{"todos"}})
+			assert.Equal(t, ErrCorrupted, err)
+
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRestore_formatUnsupported(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	rand.Read(key)
+	archive := encodeArchive(t, key, record{Header: &Manifest{Format: Format + 1}})
+
+	_, err := Restore(ctx, repository, bytes.NewReader(archive), key, Options{})
+	assert.Equal(t, ErrFormatUnsupported, err)
+
+	repository.AssertExpectations(t)
+}
+
+func TestRestore_schemaMismatch(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	rand.Read(key)
+	archive := backup(t, key)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectAggregate(rel.From("rel_schema_versions"), "max", "version").Result(version + 1)
+	})
+
+	_, err := Restore(ctx, repository, bytes.NewReader(archive), key, Options{})
+	assert.Equal(t, ErrSchemaMismatch, err)
+
+	repository.AssertExpectations(t)
+}
+
+func TestRestore_tableNotEmpty(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	rand.Read(key)
+	archive := backup(t, key)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectAggregate(rel.From("rel_schema_versions"), "max", "version").Result(version)
+		repository.ExpectCount("todos").Result(1)
+	})
+
+	_, err := Restore(ctx, repository, bytes.NewReader(archive), key, Options{Tables: []string{"todos"}})
+	assert.ErrorIs(t, err, ErrTableNotEmpty)
+
+	repository.AssertExpectations(t)
+}
+
+func TestRestore_replaceArchivedTables(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	rand.Read(key)
+	archive := backup(t, key)
+
+	// only todos is in the archive, other registered tables are left untouched.
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectDeleteAny(rel.From("todos")).Unsafe()
+		repository.ExpectInsert(verbatim{}).ForType("todos.Todo").Success().Times(3)
+		repository.ExpectExec(setval, noArgs).Result(0, 0)
+	})
+
+	manifest, err := Restore(ctx, repository, bytes.NewReader(archive), key, Options{Replace: true, Force: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []TableManifest{{Name: "todos"}}, manifest.Tables)
+
+	repository.AssertExpectations(t)
+}
+
+func TestRestore_missingTable(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	rand.Read(key)
+	archive := backup(t, key)
+
+	_, err := Restore(ctx, repository, bytes.NewReader(archive), key, Options{Tables: []string{"todos", "points"}, Replace: true})
+	assert.Equal(t, MissingTableError("points"), err)
+	assert.EqualError(t, err, "backup: table points is not in the archive")
+
+	repository.AssertExpectations(t)
+}
+
+func TestRestore_tablesRequired(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		key        = make([]byte, 32)
+	)
+
+	rand.Read(key)
+	archive := encodeArchive(t, key, record{Header: &Manifest{Format: 1, SchemaVersion: version}})
+
+	_, err := Restore(ctx, repository, bytes.NewReader(archive), key, Options{Replace: true})
+	assert.Equal(t, ErrTablesRequired, err)
+
+	repository.AssertExpectations(t)
+}
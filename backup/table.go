@@ -0,0 +1,79 @@
+package backup
+
+import (
+	"reflect"
+
+	"github.com/Fs02/go-todo-backend/maintenance"
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/Fs02/go-todo-backend/views"
+)
+
+// Table that can be backed up with its entity.
+type Table struct {
+	Name   string
+	Entity interface{}
+}
+
+// Tables that can be backed up, ordered so referenced table is restored first.
+// New table should be registered here to be included in backup.
+var Tables = []Table{
+	{Name: "scores", Entity: scores.Score{}},
+	{Name: "points", Entity: scores.Point{}},
+	{Name: "todos", Entity: todos.Todo{}},
+	{Name: "views", Entity: views.View{}},
+	{Name: "maintenances", Entity: maintenance.Maintenance{}},
+}
+
+// selectTables returns registered tables matching names in the registered order, every table is selected when names is empty.
+func selectTables(names []string) ([]Table, error) {
+	if len(names) == 0 {
+		return Tables, nil
+	}
+
+	var (
+		selected []Table
+		wanted   = make(map[string]bool, len(names))
+	)
+
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	for _, table := range Tables {
+		if wanted[table.Name] {
+			selected = append(selected, table)
+			delete(wanted, table.Name)
+		}
+	}
+
+	for name := range wanted {
+		return nil, UnknownTableError(name)
+	}
+
+	return selected, nil
+}
+
+// UnknownTableError returned when selected table is not registered.
+type UnknownTableError string
+
+// Error message.
+func (e UnknownTableError) Error() string {
+	return "backup: unknown table " + string(e)
+}
+
+// MissingTableError returned when table selected for restore is not in the archive.
+type MissingTableError string
+
+// Error message.
+func (e MissingTableError) Error() string {
+	return "backup: table " + string(e) + " is not in the archive"
+}
+
+func (t Table) slice() reflect.Value {
+	return reflect.New(reflect.SliceOf(reflect.TypeOf(t.Entity)))
+}
+
+func (t Table) entity() reflect.Value {
+	return reflect.New(reflect.TypeOf(t.Entity))
+}
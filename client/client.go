@@ -0,0 +1,241 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/search"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/Fs02/go-todo-backend/views"
+)
+
+//go:generate mockery --name=Client --case=underscore --output clienttest --outpkg clienttest
+
+// Client for todo backend api.
+// Request and response uses the same entity struct used by the server, so it's always in sync with the server version.
+type Client interface {
+	SearchTodos(ctx context.Context, result *[]todos.Todo, filter todos.Filter) error
+	CreateTodo(ctx context.Context, todo *todos.Todo) error
+	FindTodo(ctx context.Context, result *todos.Todo, id uint) error
+	UpdateTodo(ctx context.Context, todo *todos.Todo) error
+	DeleteTodo(ctx context.Context, id uint) error
+	ClearTodos(ctx context.Context) error
+	FindScore(ctx context.Context, result *scores.Score) error
+	FindPoints(ctx context.Context, result *[]scores.Point) error
+	SearchViews(ctx context.Context, result *[]views.View) error
+	CreateView(ctx context.Context, view *views.View) error
+	FindView(ctx context.Context, result *views.View, id uint) error
+	UpdateView(ctx context.Context, view *views.View) error
+	DeleteView(ctx context.Context, id uint) error
+	Search(ctx context.Context, result *search.Result, query search.Query) error
+}
+
+// Error returned by the api.
+type Error struct {
+	StatusCode int
+	Message    string `json:"error"`
+	// RetryAfter requested by the api using Retry-After header on 429 and 503 responses, zero when not set.
+	RetryAfter time.Duration `json:"-"`
+}
+
+// Error message.
+func (e Error) Error() string {
+	return fmt.Sprintf("%d: %s", e.StatusCode, e.Message)
+}
+
+// DecodeError returned when a successful response can't be decoded, it's never retried since the request already succeeded.
+type DecodeError struct {
+	StatusCode int
+	Err        error
+}
+
+// Error message.
+func (e DecodeError) Error() string {
+	return fmt.Sprintf("%d: invalid response: %s", e.StatusCode, e.Err)
+}
+
+// Unwrap returns the decoding error.
+func (e DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Option for client.
+type Option func(c *client)
+
+// WithToken sets bearer token sent on every request.
+func WithToken(token string) Option {
+	return func(c *client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient sets http client used to send request.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetry sets maximum retries and initial backoff for idempotent request that failed due to network error, server error or rate limit.
+// Backoff is doubled on every retry, Retry-After sent by the api is waited instead when present.
+func WithRetry(max int, backoff time.Duration) Option {
+	return func(c *client) {
+		c.retries = max
+		c.backoff = backoff
+	}
+}
+
+type client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+}
+
+var _ Client = (*client)(nil)
+
+// New api client, baseURL is the url where the api router is mounted.
+func New(baseURL string, options ...Option) Client {
+	c := &client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retries:    2,
+		backoff:    100 * time.Millisecond,
+	}
+
+	for i := range options {
+		options[i](c)
+	}
+
+	return c
+}
+
+func (c *client) do(ctx context.Context, method string, path string, body interface{}, result interface{}) error {
+	var (
+		payload []byte
+		err     error
+		retries = 0
+		backoff = c.backoff
+	)
+
+	if body != nil {
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	// only retry idempotent request, retrying other method may duplicate the changes.
+	if method == http.MethodGet || method == http.MethodPut || method == http.MethodDelete {
+		retries = c.retries
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = c.send(ctx, method, path, payload, result)
+		if attempt >= retries || ctx.Err() != nil || !retryable(err) {
+			return err
+		}
+
+		wait := backoff
+		if apiErr, ok := err.(Error); ok && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+			backoff *= 2
+		}
+	}
+}
+
+func (c *client) send(ctx context.Context, method string, path string, payload []byte, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		apiErr := Error{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			apiErr.RetryAfter = retryAfter(resp.Header.Get("Retry-After"))
+		}
+
+		return apiErr
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return DecodeError{StatusCode: resp.StatusCode, Err: err}
+	}
+
+	return nil
+}
+
+// retryAfter parses Retry-After header value, either delay in seconds or http date.
+func retryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+
+	return 0
+}
+
+// retryable reports whether request failed due to network error, server error or rate limit.
+// Errors building the request or decoding the response are never retried.
+func retryable(err error) bool {
+	if apiErr, ok := err.(Error); ok {
+		return apiErr.StatusCode >= 500 || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	// http client wraps every error in url.Error, including invalid url and unsupported scheme,
+	// only the ones caused by the connection are retried.
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(urlErr.Err, &netErr) || errors.Is(urlErr.Err, io.EOF) || errors.Is(urlErr.Err, io.ErrUnexpectedEOF)
+}
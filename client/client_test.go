@@ -0,0 +1,215 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Fs02/go-todo-backend/client"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_auth(t *testing.T) {
+	var (
+		ctx    = context.TODO()
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			w.Write([]byte(`{"id":1, "title":"Sleep"}`))
+		}))
+		c    = client.New(server.URL, client.WithToken("secret"))
+		todo todos.Todo
+	)
+
+	defer server.Close()
+
+	assert.Nil(t, c.FindTodo(ctx, &todo, 1))
+	assert.Equal(t, todos.Todo{ID: 1, Title: "Sleep"}, todo)
+}
+
+func TestClient_retry(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   func(c client.Client) error
+		statuses []int
+		calls    int
+		err      error
+	}{
+		{
+			name: "retry server error",
+			method: func(c client.Client) error {
+				return c.DeleteTodo(context.TODO(), 1)
+			},
+			statuses: []int{503, 503, 204},
+			calls:    3,
+		},
+		{
+			name: "give up after max retries",
+			method: func(c client.Client) error {
+				return c.DeleteTodo(context.TODO(), 1)
+			},
+			statuses: []int{503, 503, 503, 204},
+			calls:    3,
+			err:      client.Error{StatusCode: 503, Message: "Service Unavailable"},
+		},
+		{
+			name: "retry too many requests",
+			method: func(c client.Client) error {
+				return c.DeleteTodo(context.TODO(), 1)
+			},
+			statuses: []int{429, 204},
+			calls:    2,
+		},
+		{
+			name: "client error is not retried",
+			method: func(c client.Client) error {
+				return c.DeleteTodo(context.TODO(), 1)
+			},
+			statuses: []int{404},
+			calls:    1,
+			err:      client.Error{StatusCode: 404, Message: "Not Found"},
+		},
+		{
+			name: "non idempotent request is not retried",
+			method: func(c client.Client) error {
+				return c.CreateTodo(context.TODO(), &todos.Todo{Title: "Sleep"})
+			},
+			statuses: []int{503, 201},
+			calls:    1,
+			err:      client.Error{StatusCode: 503, Message: "Service Unavailable"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				calls  = 0
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(test.statuses[calls])
+					calls++
+				}))
+				c = client.New(server.URL, client.WithRetry(2, time.Millisecond))
+			)
+
+			defer server.Close()
+
+			assert.Equal(t, test.err, test.method(c))
+			assert.Equal(t, test.calls, calls)
+		})
+	}
+}
+
+func TestClient_retryAfter(t *testing.T) {
+	var (
+		calls  = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(503)
+				return
+			}
+
+			w.WriteHeader(204)
+		}))
+		ctx, cancel = context.WithTimeout(context.TODO(), 10*time.Second)
+		c           = client.New(server.URL, client.WithRetry(2, time.Hour))
+		start       = time.Now()
+	)
+
+	defer cancel()
+	defer server.Close()
+
+	assert.Nil(t, c.DeleteTodo(ctx, 1))
+	assert.Equal(t, 2, calls)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestClient_retryAfter_error(t *testing.T) {
+	var (
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(429)
+		}))
+		c = client.New(server.URL, client.WithRetry(0, time.Millisecond))
+	)
+
+	defer server.Close()
+
+	assert.Equal(t, client.Error{StatusCode: 429, Message: "Too Many Requests", RetryAfter: 2 * time.Minute}, c.ClearTodos(context.TODO()))
+}
+
+func TestClient_networkError(t *testing.T) {
+	var (
+		calls  = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls == 1 {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				assert.Nil(t, err)
+				conn.Close()
+				return
+			}
+
+			w.WriteHeader(204)
+		}))
+		c = client.New(server.URL, client.WithRetry(2, time.Millisecond))
+	)
+
+	defer server.Close()
+
+	assert.Nil(t, c.DeleteTodo(context.TODO(), 1))
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_requestError(t *testing.T) {
+	var (
+		ctx, cancel = context.WithTimeout(context.TODO(), 10*time.Second)
+		c           = client.New("ftp://todos.example.com", client.WithRetry(2, time.Hour))
+	)
+
+	defer cancel()
+
+	err := c.DeleteTodo(ctx, 1)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unsupported protocol scheme")
+}
+
+func TestClient_decodeError(t *testing.T) {
+	var (
+		calls  = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.Write([]byte(`{"id":`))
+		}))
+		c    = client.New(server.URL, client.WithRetry(2, time.Millisecond))
+		todo todos.Todo
+	)
+
+	defer server.Close()
+
+	err := c.FindTodo(context.TODO(), &todo, 1)
+	assert.IsType(t, client.DecodeError{}, err)
+	assert.EqualError(t, err, "200: invalid response: unexpected EOF")
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_contextCanceled(t *testing.T) {
+	var (
+		calls       = 0
+		ctx, cancel = context.WithCancel(context.TODO())
+		server      = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			cancel()
+			w.WriteHeader(503)
+		}))
+		c = client.New(server.URL, client.WithRetry(2, time.Millisecond))
+	)
+
+	defer server.Close()
+
+	assert.NotNil(t, c.ClearTodos(ctx))
+	assert.Equal(t, 1, calls)
+}
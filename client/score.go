@@ -0,0 +1,17 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Fs02/go-todo-backend/scores"
+)
+
+// FindScore calls GET /score.
+func (c *client) FindScore(ctx context.Context, result *scores.Score) error {
+	return c.do(ctx, "GET", "/score", nil, result)
+}
+
+// FindPoints calls GET /score/points.
+func (c *client) FindPoints(ctx context.Context, result *[]scores.Point) error {
+	return c.do(ctx, "GET", "/score/points", nil, result)
+}
@@ -0,0 +1,40 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/factories"
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_FindScore(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		result        scores.Score
+	)
+
+	repository.ExpectFind().Result(factories.Score(func(score *scores.Score) {
+		score.ID = 1
+		score.TotalPoint = 10
+	}))
+
+	assert.Nil(t, c.FindScore(ctx, &result))
+	assert.Equal(t, scores.Score{ID: 1, TotalPoint: 10}, result)
+}
+
+func TestClient_FindPoints(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		result        []scores.Point
+	)
+
+	repository.ExpectFindAll(rel.SortAsc("id"), rel.Limit(100), rel.Offset(0)).Result([]scores.Point{factories.Point(func(point *scores.Point) { point.ID = 1 })})
+
+	assert.Nil(t, c.FindPoints(ctx, &result))
+	assert.Equal(t, []scores.Point{{ID: 1, Name: "todo completed", Count: 1}}, result)
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/Fs02/go-todo-backend/search"
+)
+
+// Search calls GET /search.
+func (c *client) Search(ctx context.Context, result *search.Result, query search.Query) error {
+	params := url.Values{}
+	if query.Keyword != "" {
+		params.Set("q", query.Keyword)
+	}
+
+	if query.Completed != nil {
+		params.Set("completed", strconv.FormatBool(*query.Completed))
+	}
+
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	if query.Offset > 0 {
+		params.Set("offset", strconv.Itoa(query.Offset))
+	}
+
+	path := "/search"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	return c.do(ctx, "GET", path, nil, result)
+}
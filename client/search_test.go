@@ -0,0 +1,38 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/factories"
+	"github.com/Fs02/go-todo-backend/search"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_Search(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		completed     = true
+		result        search.Result
+		todo          = factories.Todo(func(todo *todos.Todo) {
+			todo.ID = 1
+			todo.Completed = true
+		})
+		matches = rel.Where(where.Fragment("title ILIKE ?", "%Sleep%"), where.Eq("completed", true))
+	)
+
+	repository.ExpectFindAll(matches.SortAsc("order").SortAsc("id").Limit(10).Offset(20)).Result([]todos.Todo{todo})
+	repository.ExpectCount("todos", matches).Result(21)
+
+	assert.Nil(t, c.Search(ctx, &result, search.Query{Keyword: "Sleep", Completed: &completed, Limit: 10, Offset: 20}))
+	assert.Equal(t, search.Result{
+		Todos:  []todos.Todo{todo},
+		Total:  21,
+		Facets: map[string]map[string]int{"completed": {"true": 21}},
+		Source: search.Postgres,
+	}, result)
+}
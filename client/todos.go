@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/Fs02/go-todo-backend/todos"
+)
+
+// SearchTodos calls GET /todos.
+func (c *client) SearchTodos(ctx context.Context, result *[]todos.Todo, filter todos.Filter) error {
+	query := url.Values{}
+	if filter.Keyword != "" {
+		query.Set("keyword", filter.Keyword)
+	}
+
+	if filter.Completed != nil {
+		query.Set("completed", strconv.FormatBool(*filter.Completed))
+	}
+
+	if filter.Sort != "" {
+		query.Set("sort", filter.Sort)
+	}
+
+	if filter.Limit > 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	if filter.Offset > 0 {
+		query.Set("offset", strconv.Itoa(filter.Offset))
+	}
+
+	path := "/todos"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	return c.do(ctx, "GET", path, nil, result)
+}
+
+// CreateTodo calls POST /todos.
+func (c *client) CreateTodo(ctx context.Context, todo *todos.Todo) error {
+	return c.do(ctx, "POST", "/todos", todo, todo)
+}
+
+// FindTodo calls GET /todos/{ID}.
+func (c *client) FindTodo(ctx context.Context, result *todos.Todo, id uint) error {
+	return c.do(ctx, "GET", fmt.Sprint("/todos/", id), nil, result)
+}
+
+// UpdateTodo calls PATCH /todos/{ID}.
+func (c *client) UpdateTodo(ctx context.Context, todo *todos.Todo) error {
+	return c.do(ctx, "PATCH", fmt.Sprint("/todos/", todo.ID), todo, todo)
+}
+
+// DeleteTodo calls DELETE /todos/{ID}.
+func (c *client) DeleteTodo(ctx context.Context, id uint) error {
+	return c.do(ctx, "DELETE", fmt.Sprint("/todos/", id), nil, nil)
+}
+
+// ClearTodos calls DELETE /todos.
+func (c *client) ClearTodos(ctx context.Context) error {
+	return c.do(ctx, "DELETE", "/todos", nil, nil)
+}
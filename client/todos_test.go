@@ -0,0 +1,151 @@
+package client_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/api"
+	"github.com/Fs02/go-todo-backend/client"
+	"github.com/Fs02/go-todo-backend/factories"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func serve(t *testing.T) (client.Client, *reltest.Repository) {
+	var (
+		repository = reltest.New()
+		server     = httptest.NewServer(api.NewMux(repository, api.Config{}))
+	)
+
+	t.Cleanup(func() {
+		server.Close()
+		repository.AssertExpectations(t)
+	})
+
+	return client.New(server.URL), repository
+}
+
+func TestClient_SearchTodos(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		completed     = true
+		result        []todos.Todo
+		todo          = factories.Todo(func(todo *todos.Todo) { todo.ID = 1 })
+	)
+
+	repository.ExpectFindAll(
+		rel.Select().SortAsc("order").SortAsc("id").Where(rel.Like("title", "%Sleep%").AndEq("completed", true)).Limit(100),
+	).Result([]todos.Todo{todo})
+
+	assert.Nil(t, c.SearchTodos(ctx, &result, todos.Filter{Keyword: "Sleep", Completed: &completed}))
+	assert.Equal(t, []todos.Todo{todo}, result)
+}
+
+func TestClient_SearchTodos_sort(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		result        []todos.Todo
+	)
+
+	repository.ExpectFindAll(rel.Select().SortDesc("created_at").SortAsc("id").Limit(100)).Result([]todos.Todo{})
+
+	assert.Nil(t, c.SearchTodos(ctx, &result, todos.Filter{Sort: "-created_at"}))
+	assert.Empty(t, result)
+}
+
+func TestClient_SearchTodos_page(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		result        []todos.Todo
+	)
+
+	repository.ExpectFindAll(rel.Select().SortAsc("order").SortAsc("id").Limit(10).Offset(20)).Result([]todos.Todo{})
+
+	assert.Nil(t, c.SearchTodos(ctx, &result, todos.Filter{Limit: 10, Offset: 20}))
+	assert.Empty(t, result)
+}
+
+func TestClient_CreateTodo(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		todo          = factories.Todo()
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().ForType("todos.Todo")
+	})
+
+	assert.Nil(t, c.CreateTodo(ctx, &todo))
+	assert.Equal(t, uint(1), todo.ID)
+}
+
+func TestClient_FindTodo(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		result        todos.Todo
+	)
+
+	repository.ExpectFind(where.Eq("id", 1)).Result(factories.Todo(func(todo *todos.Todo) { todo.ID = 1 }))
+
+	assert.Nil(t, c.FindTodo(ctx, &result, 1))
+	assert.Equal(t, uint(1), result.ID)
+}
+
+func TestClient_FindTodo_notFound(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		result        todos.Todo
+	)
+
+	repository.ExpectFind(where.Eq("id", 1)).NotFound()
+
+	assert.Equal(t, client.Error{StatusCode: 404, Message: "entity not found"}, c.FindTodo(ctx, &result, 1))
+}
+
+func TestClient_UpdateTodo(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		todo          = factories.Todo(func(todo *todos.Todo) { todo.ID = 1 })
+	)
+
+	repository.ExpectFind(where.Eq("id", 1)).Result(todo)
+	repository.ExpectUpdate().ForType("todos.Todo")
+
+	todo.Title = "Wake"
+	assert.Nil(t, c.UpdateTodo(ctx, &todo))
+	assert.Equal(t, "Wake", todo.Title)
+}
+
+func TestClient_DeleteTodo(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+	)
+
+	repository.ExpectFind(where.Eq("id", 1)).Result(factories.Todo(func(todo *todos.Todo) { todo.ID = 1 }))
+	repository.ExpectDelete().ForType("todos.Todo")
+
+	assert.Nil(t, c.DeleteTodo(ctx, 1))
+}
+
+func TestClient_ClearTodos(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+	)
+
+	repository.ExpectDeleteAny(rel.From("todos")).Unsafe()
+
+	assert.Nil(t, c.ClearTodos(ctx))
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Fs02/go-todo-backend/views"
+)
+
+// SearchViews calls GET /views.
+func (c *client) SearchViews(ctx context.Context, result *[]views.View) error {
+	return c.do(ctx, "GET", "/views", nil, result)
+}
+
+// CreateView calls POST /views.
+func (c *client) CreateView(ctx context.Context, view *views.View) error {
+	return c.do(ctx, "POST", "/views", view, view)
+}
+
+// FindView calls GET /views/{ID}.
+func (c *client) FindView(ctx context.Context, result *views.View, id uint) error {
+	return c.do(ctx, "GET", fmt.Sprint("/views/", id), nil, result)
+}
+
+// UpdateView calls PATCH /views/{ID}.
+func (c *client) UpdateView(ctx context.Context, view *views.View) error {
+	return c.do(ctx, "PATCH", fmt.Sprint("/views/", view.ID), view, view)
+}
+
+// DeleteView calls DELETE /views/{ID}.
+func (c *client) DeleteView(ctx context.Context, id uint) error {
+	return c.do(ctx, "DELETE", fmt.Sprint("/views/", id), nil, nil)
+}
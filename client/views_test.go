@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/views"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_SearchViews(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		result        []views.View
+		view          = views.View{ID: 1, Name: "Done"}
+	)
+
+	repository.ExpectFindAll(rel.SortAsc("id"), rel.Limit(100), rel.Offset(0)).Result([]views.View{view})
+
+	assert.Nil(t, c.SearchViews(ctx, &result))
+	assert.Equal(t, []views.View{view}, result)
+}
+
+func TestClient_CreateView(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		view          = views.View{Name: "Done", Sort: "-updated_at"}
+	)
+
+	repository.ExpectInsert().ForType("views.View")
+
+	assert.Nil(t, c.CreateView(ctx, &view))
+	assert.Equal(t, uint(1), view.ID)
+}
+
+func TestClient_FindView(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		result        views.View
+	)
+
+	repository.ExpectFind(where.Eq("id", 1)).Result(views.View{ID: 1, Name: "Done"})
+
+	assert.Nil(t, c.FindView(ctx, &result, 1))
+	assert.Equal(t, "Done", result.Name)
+}
+
+func TestClient_UpdateView(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+		view          = views.View{ID: 1, Name: "Done"}
+	)
+
+	repository.ExpectFind(where.Eq("id", 1)).Result(view)
+	repository.ExpectUpdate().ForType("views.View")
+
+	view.Fields = "title"
+	assert.Nil(t, c.UpdateView(ctx, &view))
+	assert.Equal(t, "title", view.Fields)
+}
+
+func TestClient_DeleteView(t *testing.T) {
+	var (
+		ctx           = context.TODO()
+		c, repository = serve(t)
+	)
+
+	repository.ExpectFind(where.Eq("id", 1)).Result(views.View{ID: 1, Name: "Done"})
+	repository.ExpectDelete().ForType("views.View")
+
+	assert.Nil(t, c.DeleteView(ctx, 1))
+}
@@ -2,40 +2,85 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
+	"flag"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/Fs02/go-todo-backend/api"
+	"github.com/Fs02/go-todo-backend/api/handler"
+	"github.com/Fs02/go-todo-backend/db"
+	"github.com/Fs02/go-todo-backend/diagnostics"
+	"github.com/Fs02/go-todo-backend/events"
+	"github.com/Fs02/go-todo-backend/maintenance"
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/search"
+	"github.com/Fs02/go-todo-backend/secrets"
 	"github.com/go-rel/postgres"
 	"github.com/go-rel/rel"
-	_ "github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
 var (
 	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "main")))
 	shutdowns []func() error
+	provider  = secrets.MustOpen()
+	dev       = flag.Bool("dev", false, "run pending migrations and seed demo data on start")
 )
 
 func main() {
+	flag.Parse()
+
 	var (
-		ctx        = context.Background()
-		port       = os.Getenv("PORT")
-		repository = initRepository()
-		mux        = api.NewMux(repository)
-		server     = http.Server{
+		ctx                                  = context.Background()
+		port                                 = os.Getenv("PORT")
+		repository, maintenance, diagnostics = initRepository()
+		events                               = events.New(repository)
+		engine                               = initSearch(ctx, repository)
+		maxBody, _                           = strconv.ParseInt(os.Getenv("MAX_BODY_SIZE"), 10, 64)
+		queryBudget, _                       = strconv.Atoi(os.Getenv("QUERY_BUDGET"))
+		pagination, err                      = handler.ParsePagination(os.Getenv("PAGINATION"))
+		mux                                  = api.NewMux(repository, api.Config{
+			AdminToken:  provider.MustGet(ctx, "ADMIN_TOKEN"),
+			Maintenance: maintenance,
+			Events:      events,
+			Diagnostics: diagnostics,
+			MaxBodySize: maxBody,
+			QueryBudget: queryBudget,
+			Pagination:  pagination,
+			Search:      engine,
+		})
+		server = http.Server{
 			Addr:    ":" + port,
 			Handler: mux,
 		}
 		shutdown = make(chan struct{})
 	)
 
-	go gracefulShutdown(ctx, &server, shutdown)
+	if err != nil {
+		logger.Fatal("config error", zap.Error(err))
+	}
+
+	// events registry is shared, so subscribers are registered here once instead of by api.NewMux.
+	scores.Subscribe(events, scores.New(repository))
+	if engine != nil {
+		search.Subscribe(events, search.New(repository, engine))
+	}
+
+	if *dev {
+		initDev(ctx, repository)
+	}
+
+	// background workers are stopped before the database is closed.
+	workers, stopWorkers := context.WithCancel(ctx)
+
+	go refreshMaintenance(workers, maintenance)
+	go gracefulShutdown(ctx, &server, events, stopWorkers, shutdown)
 
 	logger.Info("server starting: http://localhost" + server.Addr)
 	if err := server.ListenAndServe(); err != http.ErrServerClosed {
@@ -45,25 +90,33 @@ func main() {
 	<-shutdown
 }
 
-func initRepository() rel.Repository {
+func initRepository() (rel.Repository, maintenance.Service, diagnostics.Service) {
 	var (
-		logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "repository")))
-		dsn       = fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
-			os.Getenv("POSTGRESQL_USERNAME"),
-			os.Getenv("POSTGRESQL_PASSWORD"),
-			os.Getenv("POSTGRESQL_HOST"),
-			os.Getenv("POSTGRESQL_PORT"),
-			os.Getenv("POSTGRESQL_DATABASE"))
+		logger, _     = zap.NewProduction(zap.Fields(zap.String("type", "repository")))
+		sampleRate, _ = strconv.ParseFloat(os.Getenv("QUERY_SAMPLE_RATE"), 64)
+		primary       = openAdapter(os.Getenv("POSTGRESQL_HOST"))
+		replica       rel.Adapter
 	)
 
-	adapter, err := postgres.Open(dsn)
-	if err != nil {
-		logger.Fatal(err.Error(), zap.Error(err))
+	// replica is optional, reads are only routed to it while read only.
+	if host := os.Getenv("POSTGRESQL_REPLICA_HOST"); host != "" {
+		replica = openAdapter(host)
 	}
-	// add to graceful shutdown list.
-	shutdowns = append(shutdowns, adapter.Close)
 
-	repository := rel.New(adapter)
+	// maintenance uses unguarded repository, so read only mode can still be disabled using admin endpoint.
+	state := maintenance.New(rel.New(primary), os.Getenv("MAINTENANCE") == "true", os.Getenv("READ_ONLY") == "true")
+
+	// sampled queries are explained using a separate single connection pool, so explaining never takes a connection from requests.
+	explain := openDatabase(os.Getenv("POSTGRESQL_HOST"))
+	explain.SetMaxOpenConns(1)
+	shutdowns = append(shutdowns, explain.Close)
+
+	sampler := diagnostics.New(diagnostics.NewExplainer(explain), sampleRate)
+	if replica != nil {
+		replica = diagnostics.Wrap(replica, sampler)
+	}
+
+	repository := rel.New(maintenance.Wrap(diagnostics.Wrap(primary, sampler), replica, state))
 	repository.Instrumentation(func(ctx context.Context, op string, message string, args ...interface{}) func(err error) {
 		// no op for rel functions.
 		if strings.HasPrefix(op, "rel-") {
@@ -82,10 +135,84 @@ func initRepository() rel.Repository {
 		}
 	})
 
-	return repository
+	return repository, state, sampler
 }
 
-func gracefulShutdown(ctx context.Context, server *http.Server, shutdown chan struct{}) {
+func openAdapter(host string) rel.Adapter {
+	adapter := postgres.New(openDatabase(host))
+	// add to graceful shutdown list.
+	shutdowns = append(shutdowns, adapter.Close)
+
+	return adapter
+}
+
+func openDatabase(host string) *sql.DB {
+	var (
+		lifetime, _ = time.ParseDuration(os.Getenv("POSTGRESQL_CONN_MAX_LIFETIME"))
+		database    = sql.OpenDB(secrets.NewPostgresConnector(provider, host))
+	)
+
+	if lifetime == 0 {
+		lifetime = 30 * time.Minute
+	}
+
+	// recycle connections, so connections opened using rotated password are closed eventually.
+	database.SetConnMaxLifetime(lifetime)
+
+	return database
+}
+
+// initSearch engine, search is served by postgres when SEARCH_URL is not set.
+func initSearch(ctx context.Context, repository rel.Repository) search.Engine {
+	address := os.Getenv("SEARCH_URL")
+	if address == "" {
+		return nil
+	}
+
+	engine := search.Meilisearch{
+		Address: address,
+		Key:     provider.MustGet(ctx, "SEARCH_KEY"),
+	}
+
+	// index settings are applied on start, otherwise filtered search fails at the engine until bin/reindex is run.
+	if err := search.New(repository, engine).Configure(ctx); err != nil {
+		logger.Error("search index settings can't be applied, filtered search falls back to postgres until the engine is configured", zap.String("engine", engine.Name()), zap.Error(err))
+	}
+
+	return engine
+}
+
+func initDev(ctx context.Context, repository rel.Repository) {
+	logger.Info("running in dev mode")
+
+	if err := db.Migrate(ctx, repository); err != nil {
+		logger.Fatal("migration error", zap.Error(err))
+	}
+
+	if err := db.Seed(ctx, repository); err != nil {
+		logger.Fatal("seed error", zap.Error(err))
+	}
+}
+
+// refreshMaintenance periodically, so maintenance updated from other instance is also applied in this instance.
+func refreshMaintenance(ctx context.Context, maintenance maintenance.Service) {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := maintenance.Refresh(ctx); err != nil {
+			logger.Error("refresh maintenance error", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func gracefulShutdown(ctx context.Context, server *http.Server, events events.Service, stopWorkers context.CancelFunc, shutdown chan struct{}) {
 	var (
 		sigint = make(chan os.Signal, 1)
 	)
@@ -100,6 +227,10 @@ func gracefulShutdown(ctx context.Context, server *http.Server, shutdown chan st
 		logger.Fatal("shutdown error", zap.Error(err))
 	}
 
+	// async event handlers and background workers might still be using the database.
+	events.Wait()
+	stopWorkers()
+
 	// close any other modules.
 	for i := range shutdowns {
 		shutdowns[i]()
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Fs02/go-todo-backend/backup"
+	"github.com/Fs02/go-todo-backend/secrets"
+	"github.com/go-rel/postgres"
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "archive")))
+)
+
+const usage = `usage: archive <command> [flags]
+
+commands:
+  backup   dump tables into an encrypted archive
+  restore  restore tables from an encrypted archive
+
+archive is encrypted using BACKUP_KEY, generate one using: openssl rand -base64 32
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var (
+		ctx     = context.Background()
+		command = os.Args[1]
+		flags   = flag.NewFlagSet(command, flag.ExitOnError)
+		tables  = flags.String("tables", "", "comma separated tables, every table is selected when empty")
+		file    = flags.String("file", "", "archive path, use - for stdout/stdin (eg: to stream from/to s3 using aws s3 cp)")
+		batch   = flags.Int("batch", 1000, "number of rows loaded per batch when backing up")
+		replace = flags.Bool("replace", false, "delete existing rows before restoring")
+		force   = flags.Bool("force", false, "restore archive created from different schema version")
+	)
+
+	flags.Parse(os.Args[2:])
+
+	provider := secrets.MustOpen()
+
+	key, err := backup.ParseKey(provider.MustGet(ctx, "BACKUP_KEY"))
+	if err != nil {
+		logger.Fatal("invalid BACKUP_KEY", zap.Error(err))
+	}
+
+	var (
+		repository = initRepository(provider)
+		options    = backup.Options{
+			BatchSize: *batch,
+			Replace:   *replace,
+			Force:     *force,
+		}
+	)
+
+	if *tables != "" {
+		options.Tables = strings.Split(*tables, ",")
+	}
+
+	switch command {
+	case "backup":
+		if *file == "" {
+			*file = fmt.Sprintf("backup-%s.bak", time.Now().UTC().Format("20060102150405"))
+		}
+
+		err = runBackup(ctx, repository, *file, key, options)
+	case "restore":
+		err = runRestore(ctx, repository, *file, key, options)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		logger.Fatal(command+" error", zap.Error(err))
+	}
+}
+
+func initRepository(provider *secrets.Cache) rel.Repository {
+	connector := secrets.NewPostgresConnector(provider, os.Getenv("POSTGRESQL_HOST"))
+	return rel.New(postgres.New(sql.OpenDB(connector)))
+}
+
+func runBackup(ctx context.Context, repository rel.Repository, file string, key []byte, options backup.Options) error {
+	var (
+		w io.WriteCloser = os.Stdout
+	)
+
+	if file != "-" {
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err != nil {
+			return err
+		}
+
+		w = f
+	}
+
+	manifest, err := backup.Backup(ctx, repository, w, key, options)
+	if err != nil {
+		w.Close()
+
+		// partial archive can't be restored, remove it so it's not mistaken as a valid backup.
+		if file != "-" {
+			os.Remove(file)
+		}
+
+		return err
+	}
+
+	logger.Info("backup created", zap.String("file", file), zap.Int("schema_version", manifest.SchemaVersion), zap.Any("tables", manifest.Tables))
+	return w.Close()
+}
+
+func runRestore(ctx context.Context, repository rel.Repository, file string, key []byte, options backup.Options) error {
+	var (
+		r io.ReadCloser = os.Stdin
+	)
+
+	if file == "" {
+		return fmt.Errorf("archive file is required")
+	}
+
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+
+		r = f
+	}
+	defer r.Close()
+
+	manifest, err := backup.Restore(ctx, repository, r, key, options)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("backup restored", zap.String("file", file), zap.Time("created_at", manifest.CreatedAt), zap.Int("schema_version", manifest.SchemaVersion))
+	return nil
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/Fs02/go-todo-backend/factories"
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/secrets"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/postgres"
+	"github.com/go-rel/rel"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// maxInsertBatch keeps bulk insert of todos and points (5 columns each) under postgres limit of 65535 bind parameters.
+const maxInsertBatch = 65535 / 5
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "loadgen")))
+	titles    = []string{"Wake up", "Make coffee", "Write some code", "Review pull request", "Go for a walk", "Read a book", "Sleep"}
+)
+
+// config of generated data distribution.
+type config struct {
+	total     int
+	batch     int
+	completed float64
+	spread    time.Duration
+	copy      bool
+	seed      int64
+}
+
+// validate config, batch size is capped when using bulk insert.
+func (c *config) validate() error {
+	switch {
+	case c.total < 0:
+		return errors.New("-todos must not be negative")
+	case c.batch <= 0:
+		return errors.New("-batch must be positive")
+	case c.spread < 0:
+		return errors.New("-spread must not be negative")
+	case c.completed < 0 || c.completed > 1:
+		return errors.New("-completed must be between 0 and 1")
+	}
+
+	if !c.copy && c.batch > maxInsertBatch {
+		logger.Warn("batch is capped to stay under bind parameters limit", zap.Int("batch", maxInsertBatch))
+		c.batch = maxInsertBatch
+	}
+
+	return nil
+}
+
+func main() {
+	var (
+		ctx = context.Background()
+		cfg config
+	)
+
+	flag.IntVar(&cfg.total, "todos", 1000000, "number of todos to generate")
+	flag.IntVar(&cfg.batch, "batch", 10000, "number of rows inserted per batch")
+	flag.Float64Var(&cfg.completed, "completed", 0.3, "ratio of completed todos, each completed todo also generates a point")
+	flag.DurationVar(&cfg.spread, "spread", 365*24*time.Hour, "spread created_at of generated rows over this duration until now")
+	flag.BoolVar(&cfg.copy, "copy", true, "use postgres COPY instead of bulk insert")
+	flag.Int64Var(&cfg.seed, "seed", time.Now().UnixNano(), "random seed, use the same seed to generate the same data")
+	flag.Parse()
+
+	if err := cfg.validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	database := sql.OpenDB(secrets.NewPostgresConnector(secrets.MustOpen(), os.Getenv("POSTGRESQL_HOST")))
+	defer database.Close()
+
+	var (
+		repository = rel.New(postgres.New(database))
+		rnd        = rand.New(rand.NewSource(cfg.seed))
+		now        = time.Now()
+		score      = factories.CreateScore(ctx, repository)
+		start      = time.Now()
+		points     int
+	)
+
+	logger.Info("generating", zap.Int("todos", cfg.total), zap.Int64("seed", cfg.seed), zap.Bool("copy", cfg.copy))
+
+	for offset := 0; offset < cfg.total; offset += cfg.batch {
+		size := cfg.batch
+		if remaining := cfg.total - offset; remaining < size {
+			size = remaining
+		}
+
+		var (
+			todoRows   = make([]todos.Todo, size)
+			pointsRows []scores.Point
+		)
+
+		for i := range todoRows {
+			todoRows[i] = generateTodo(rnd, cfg, now, offset+i)
+
+			if todoRows[i].Completed {
+				pointsRows = append(pointsRows, factories.Point(func(point *scores.Point) {
+					point.ScoreID = score.ID
+					point.CreatedAt = todoRows[i].UpdatedAt
+					point.UpdatedAt = todoRows[i].UpdatedAt
+				}))
+			}
+		}
+
+		var err error
+		if cfg.copy {
+			err = copyRows(ctx, database, todoRows, pointsRows)
+		} else {
+			err = insertRows(ctx, repository, todoRows, pointsRows)
+		}
+
+		if err != nil {
+			logger.Fatal("insert error", zap.Error(err), zap.Int("offset", offset))
+		}
+
+		points += len(pointsRows)
+		logger.Info("progress", zap.Int("todos", offset+size), zap.Int("points", points), zap.Duration("elapsed", time.Since(start)))
+	}
+
+	score.TotalPoint = points
+	repository.MustUpdate(ctx, &score)
+
+	logger.Info("done", zap.Int("todos", cfg.total), zap.Int("points", points), zap.Duration("elapsed", time.Since(start)))
+}
+
+func generateTodo(rnd *rand.Rand, cfg config, now time.Time, i int) todos.Todo {
+	var (
+		createdAt = now.Add(-time.Duration(rnd.Int63n(int64(cfg.spread) + 1)))
+		updatedAt = createdAt.Add(time.Duration(rnd.Int63n(int64(now.Sub(createdAt)) + 1)))
+	)
+
+	return factories.Todo(func(todo *todos.Todo) {
+		todo.Title = fmt.Sprint(titles[rnd.Intn(len(titles))], " #", i)
+		todo.Order = i
+		todo.Completed = rnd.Float64() < cfg.completed
+		todo.CreatedAt = createdAt
+		todo.UpdatedAt = updatedAt
+	})
+}
+
+func insertRows(ctx context.Context, repository rel.Repository, todoRows []todos.Todo, pointRows []scores.Point) error {
+	return repository.Transaction(ctx, func(ctx context.Context) error {
+		if err := repository.InsertAll(ctx, &todoRows); err != nil {
+			return err
+		}
+
+		if len(pointRows) == 0 {
+			return nil
+		}
+
+		return repository.InsertAll(ctx, &pointRows)
+	})
+}
+
+func copyRows(ctx context.Context, database *sql.DB, todoRows []todos.Todo, pointRows []scores.Point) error {
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("todos", "title", "order", "completed", "created_at", "updated_at"))
+	if err != nil {
+		return err
+	}
+
+	for _, todo := range todoRows {
+		if _, err := stmt.ExecContext(ctx, todo.Title, todo.Order, todo.Completed, todo.CreatedAt, todo.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := flush(ctx, stmt); err != nil {
+		return err
+	}
+
+	stmt, err = tx.PrepareContext(ctx, pq.CopyIn("points", "name", "count", "score_id", "created_at", "updated_at"))
+	if err != nil {
+		return err
+	}
+
+	for _, point := range pointRows {
+		if _, err := stmt.ExecContext(ctx, point.Name, point.Count, point.ScoreID, point.CreatedAt, point.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := flush(ctx, stmt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// flush buffered copy data by executing the statement without arguments.
+func flush(ctx context.Context, stmt *sql.Stmt) error {
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return err
+	}
+
+	return stmt.Close()
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"os"
+
+	"github.com/Fs02/go-todo-backend/db"
+	"github.com/Fs02/go-todo-backend/secrets"
+	"github.com/go-rel/postgres"
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "migrate")))
+	rollback  = flag.Bool("rollback", false, "rollback the last applied migration instead of applying pending migrations")
+)
+
+// migrate applies migrations registered in migrations.Migrations using db.Migrate.
+// unlike rel cli, it honours DisableTransaction, so online migrations (concurrent index and backfill) run outside transaction.
+func main() {
+	flag.Parse()
+
+	var (
+		ctx        = context.Background()
+		repository = initRepository()
+		err        error
+	)
+
+	if *rollback {
+		err = db.Rollback(ctx, repository)
+	} else {
+		err = db.Migrate(ctx, repository)
+	}
+
+	if err != nil {
+		logger.Fatal("migration error", zap.Error(err))
+	}
+}
+
+func initRepository() rel.Repository {
+	connector := secrets.NewPostgresConnector(secrets.MustOpen(), os.Getenv("POSTGRESQL_HOST"))
+	return rel.New(postgres.New(sql.OpenDB(connector)))
+}
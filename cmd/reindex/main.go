@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"os"
+
+	"github.com/Fs02/go-todo-backend/search"
+	"github.com/Fs02/go-todo-backend/secrets"
+	"github.com/go-rel/postgres"
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "reindex")))
+	batch     = flag.Int("batch", search.DefaultBatchSize, "number of todos loaded and indexed per batch")
+)
+
+func main() {
+	flag.Parse()
+
+	var (
+		ctx      = context.Background()
+		provider = secrets.MustOpen()
+		address  = os.Getenv("SEARCH_URL")
+	)
+
+	if address == "" {
+		logger.Fatal("SEARCH_URL is required")
+	}
+
+	var (
+		repository = initRepository(provider)
+		engine     = search.Meilisearch{
+			Address: address,
+			Key:     provider.MustGet(ctx, "SEARCH_KEY"),
+		}
+		service = search.New(repository, engine)
+	)
+
+	if err := service.Reindex(ctx, *batch); err != nil {
+		logger.Fatal("reindex error", zap.Error(err))
+	}
+}
+
+func initRepository(provider *secrets.Cache) rel.Repository {
+	return rel.New(postgres.New(sql.OpenDB(secrets.NewPostgresConnector(provider, os.Getenv("POSTGRESQL_HOST")))))
+}
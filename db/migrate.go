@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Fs02/go-todo-backend/db/migrations"
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "db")))
+)
+
+// version is stored in the same table used by rel cli, so migrations applied from code are recognized by `rel migrate` and vice versa.
+type version struct {
+	ID        int
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (version) Table() string {
+	return "rel_schema_versions"
+}
+
+// Migrate applies every pending migration, each migration is applied inside its own transaction unless it disables transaction.
+func Migrate(ctx context.Context, repository rel.Repository) error {
+	var (
+		schema   rel.Schema
+		versions []version
+		applied  = make(map[int]bool)
+	)
+
+	schema.CreateTableIfNotExists("rel_schema_versions", func(t *rel.Table) {
+		t.ID("id")
+		t.BigInt("version", rel.Unsigned(true), rel.Unique(true))
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+	})
+
+	if err := apply(ctx, repository, schema); err != nil {
+		return err
+	}
+
+	if err := repository.FindAll(ctx, &versions, rel.SortAsc("version")); err != nil {
+		return err
+	}
+
+	for _, v := range versions {
+		applied[v.Version] = true
+	}
+
+	for _, migration := range migrations.Migrations {
+		if applied[migration.Version] {
+			continue
+		}
+
+		logger.Info("migrating", zap.Int("version", migration.Version), zap.String("name", migration.Name))
+
+		var schema rel.Schema
+		migration.Migrate(&schema)
+
+		if migration.DisableTransaction {
+			if err := migrateWithoutTransaction(ctx, repository, migration.Version, schema); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err := repository.Transaction(ctx, func(ctx context.Context) error {
+			if err := repository.Insert(ctx, &version{Version: migration.Version}); err != nil {
+				return err
+			}
+
+			return apply(migrations.InTransaction(ctx), repository, schema)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Rollback reverts the last applied migration, it's reverted inside a transaction unless the migration disables transaction.
+func Rollback(ctx context.Context, repository rel.Repository) error {
+	var (
+		last   version
+		schema rel.Schema
+	)
+
+	if err := repository.Find(ctx, &last, rel.SortDesc("version")); err != nil {
+		if errors.Is(err, rel.ErrNotFound) {
+			logger.Info("no migration to rollback")
+			return nil
+		}
+
+		return err
+	}
+
+	migration, ok := find(last.Version)
+	if !ok {
+		return fmt.Errorf("db: migration %d is not registered in migrations.Migrations", last.Version)
+	}
+
+	logger.Info("rolling back", zap.Int("version", migration.Version), zap.String("name", migration.Name))
+	migration.Rollback(&schema)
+
+	if migration.DisableTransaction {
+		if err := apply(ctx, repository, schema); err != nil {
+			return err
+		}
+
+		return repository.Delete(ctx, &last)
+	}
+
+	return repository.Transaction(ctx, func(ctx context.Context) error {
+		if err := repository.Delete(ctx, &last); err != nil {
+			return err
+		}
+
+		return apply(migrations.InTransaction(ctx), repository, schema)
+	})
+}
+
+func find(v int) (migrations.Migration, bool) {
+	for _, migration := range migrations.Migrations {
+		if migration.Version == v {
+			return migration, true
+		}
+	}
+
+	return migrations.Migration{}, false
+}
+
+// migrateWithoutTransaction applies each statement on its own and only records the version once all of them succeed.
+// Statements that already succeeded are not rolled back when a later one fails, online helpers guard against this (IF NOT EXISTS, dropping INVALID index
+// and leftover constraint first), so a migration built only from them can be retried. Raw statements used in such migration must be written the same way.
+func migrateWithoutTransaction(ctx context.Context, repository rel.Repository, v int, schema rel.Schema) error {
+	if err := apply(ctx, repository, schema); err != nil {
+		return err
+	}
+
+	return repository.Insert(ctx, &version{Version: v})
+}
+
+func apply(ctx context.Context, repository rel.Repository, schema rel.Schema) error {
+	adapter := repository.Adapter(ctx)
+
+	for _, migration := range schema.Migrations {
+		var err error
+		if fn, ok := migration.(rel.Do); ok {
+			err = fn(ctx, repository)
+		} else {
+			err = adapter.Apply(ctx, migration)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
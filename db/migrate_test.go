@@ -0,0 +1,168 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/db/migrations"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		applied    = []version{{ID: 1, Version: migrations.Migrations[0].Version}}
+	)
+
+	repository.ExpectFindAll(rel.SortAsc("version")).Result(applied)
+	for _, migration := range migrations.Migrations[1:] {
+		if migration.DisableTransaction {
+			repository.ExpectInsert().For(&version{Version: migration.Version})
+			continue
+		}
+
+		repository.ExpectTransaction(func(repository *reltest.Repository) {
+			repository.ExpectInsert().For(&version{Version: migration.Version})
+		})
+	}
+
+	assert.Nil(t, Migrate(ctx, repository))
+	repository.AssertExpectations(t)
+}
+
+func TestMigrate_disableTransaction(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		backfilled bool
+		original   = migrations.Migrations
+	)
+
+	t.Cleanup(func() { migrations.Migrations = original })
+	migrations.Migrations = []migrations.Migration{
+		{
+			Version: 20261710100000,
+			Name:    "backfill",
+			Migrate: func(schema *rel.Schema) {
+				schema.Do(func(ctx context.Context, repository rel.Repository) error {
+					backfilled = true
+					return nil
+				})
+			},
+			DisableTransaction: true,
+		},
+	}
+
+	repository.ExpectFindAll(rel.SortAsc("version")).Result([]version{})
+	repository.ExpectInsert().For(&version{Version: 20261710100000})
+
+	assert.Nil(t, Migrate(ctx, repository))
+	assert.True(t, backfilled)
+	repository.AssertExpectations(t)
+}
+
+func TestMigrate_findError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectFindAll(rel.SortAsc("version")).ConnectionClosed()
+
+	assert.Equal(t, reltest.ErrConnectionClosed, Migrate(ctx, repository))
+	repository.AssertExpectations(t)
+}
+
+func TestMigrate_insertError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectFindAll(rel.SortAsc("version")).Result([]version{})
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&version{Version: migrations.Migrations[0].Version}).ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, Migrate(ctx, repository))
+	repository.AssertExpectations(t)
+}
+
+func TestRollback(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		last       = version{ID: 2, Version: migrations.Migrations[len(migrations.Migrations)-1].Version}
+	)
+
+	repository.ExpectFind(rel.SortDesc("version")).Result(last)
+	if migrations.Migrations[len(migrations.Migrations)-1].DisableTransaction {
+		repository.ExpectDelete().For(&last)
+	} else {
+		repository.ExpectTransaction(func(repository *reltest.Repository) {
+			repository.ExpectDelete().For(&last)
+		})
+	}
+
+	assert.Nil(t, Rollback(ctx, repository))
+	repository.AssertExpectations(t)
+}
+
+func TestRollback_disableTransaction(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		reverted   bool
+		original   = migrations.Migrations
+		last       = version{ID: 1, Version: 20261710100000}
+	)
+
+	t.Cleanup(func() { migrations.Migrations = original })
+	migrations.Migrations = []migrations.Migration{
+		{
+			Version: 20261710100000,
+			Name:    "backfill",
+			Rollback: func(schema *rel.Schema) {
+				schema.Do(func(ctx context.Context, repository rel.Repository) error {
+					reverted = true
+					return nil
+				})
+			},
+			DisableTransaction: true,
+		},
+	}
+
+	repository.ExpectFind(rel.SortDesc("version")).Result(last)
+	repository.ExpectDelete().For(&last)
+
+	assert.Nil(t, Rollback(ctx, repository))
+	assert.True(t, reverted)
+	repository.AssertExpectations(t)
+}
+
+func TestRollback_nothingApplied(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectFind(rel.SortDesc("version")).NotFound()
+
+	assert.Nil(t, Rollback(ctx, repository))
+	repository.AssertExpectations(t)
+}
+
+func TestRollback_unknownVersion(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectFind(rel.SortDesc("version")).Result(version{ID: 1, Version: 1})
+
+	assert.EqualError(t, Rollback(ctx, repository), "db: migration 1 is not registered in migrations.Migrations")
+	repository.AssertExpectations(t)
+}
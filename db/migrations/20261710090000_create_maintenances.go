@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreateMaintenances definition
+func MigrateCreateMaintenances(schema *rel.Schema) {
+	schema.CreateTable("maintenances", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+		t.Bool("enabled")
+		t.String("message")
+		t.Int("retry_after")
+	})
+}
+
+// RollbackCreateMaintenances definition
+func RollbackCreateMaintenances(schema *rel.Schema) {
+	schema.DropTable("maintenances")
+}
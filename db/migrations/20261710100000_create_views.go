@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateCreateViews definition
+func MigrateCreateViews(schema *rel.Schema) {
+	schema.CreateTable("views", func(t *rel.Table) {
+		t.ID("id")
+		t.DateTime("created_at")
+		t.DateTime("updated_at")
+		t.String("name")
+		t.String("keyword")
+		t.Bool("completed")
+		t.String("sort")
+		t.String("fields")
+	})
+}
+
+// RollbackCreateViews definition
+func RollbackCreateViews(schema *rel.Schema) {
+	schema.DropTable("views")
+}
@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"github.com/go-rel/rel"
+)
+
+// MigrateAddReadOnlyToMaintenances definition
+func MigrateAddReadOnlyToMaintenances(schema *rel.Schema) {
+	schema.AddColumn("maintenances", "read_only", rel.Bool, rel.Default(false))
+}
+
+// RollbackAddReadOnlyToMaintenances definition
+func RollbackAddReadOnlyToMaintenances(schema *rel.Schema) {
+	schema.DropColumn("maintenances", "read_only")
+}
@@ -0,0 +1,127 @@
+package migrations
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-rel/rel"
+)
+
+var (
+	rawCreateIndex  = regexp.MustCompile(`(?is)\bcreate\s+(?:unique\s+)?index\b.*?\bon\s+(?:only\s+)?"?(\w+)"?`)
+	rawConstraint   = regexp.MustCompile(`(?i)\badd\s+constraint\b`)
+	rawAlterType    = regexp.MustCompile(`(?i)\balter\s+column\s+\S+\s+(?:set\s+data\s+)?type\b`)
+	rawAlterTable   = regexp.MustCompile(`(?i)\balter\s+table\s+(?:if\s+exists\s+)?(?:only\s+)?"?(\w+)"?`)
+	rawNotNullCheck = regexp.MustCompile(`(?is)\badd\s+constraint\s+"?(\w+)"?\s+check\s*\(\s*"?(\w+)"?\s+is\s+not\s+null\s*\)`)
+	rawSetNotNull   = regexp.MustCompile(`(?i)\balter\s+column\s+"?(\w+)"?\s+set\s+not\s+null\b`)
+	rawValidate     = regexp.MustCompile(`(?i)\bvalidate\s+constraint\s+"?(\w+)"?`)
+	rawConcurrent   = regexp.MustCompile(`(?i)\bconcurrently\b`)
+)
+
+// Lint migration for operations that lock existing table or break the running application during deployment.
+// Operations on tables created by the same migration are always allowed since no one is using it yet.
+func Lint(migration Migration) []error {
+	var (
+		schema  rel.Schema
+		errs    []error
+		created = make(map[string]bool)
+		invalid = func(format string, args ...interface{}) {
+			errs = append(errs, fmt.Errorf("%d_%s: "+format, append([]interface{}{migration.Version, migration.Name}, args...)...))
+		}
+		// checks maps NOT NULL check constraint (table.constraint) to its column, validated contains columns (table.column) with validated check.
+		checks    = make(map[string]string)
+		validated = make(map[string]bool)
+	)
+
+	migration.Migrate(&schema)
+
+	for _, m := range schema.Migrations {
+		switch op := m.(type) {
+		case rel.Table:
+			switch op.Op {
+			case rel.SchemaCreate:
+				created[op.Name] = true
+			case rel.SchemaRename:
+				invalid("renaming table %s breaks running application, create a new table and backfill it instead", op.Name)
+			case rel.SchemaAlter:
+				if !created[op.Name] {
+					lintAlterTable(op, invalid)
+				}
+			}
+		case rel.Index:
+			if op.Op == rel.SchemaCreate && !created[op.Table] {
+				invalid("creating index %s blocks writes to %s, use CreateIndexConcurrently instead", op.Name, op.Table)
+			}
+		case rel.Raw:
+			var (
+				stmt  = string(op)
+				table string
+			)
+
+			if match := rawAlterTable.FindStringSubmatch(stmt); match != nil {
+				table = match[1]
+			}
+
+			if match := rawCreateIndex.FindStringSubmatch(stmt); match != nil && !rawConcurrent.MatchString(stmt) && !created[match[1]] {
+				invalid("creating index blocks writes to %s, use CreateIndexConcurrently instead", match[1])
+			}
+
+			if rawConstraint.MatchString(stmt) && !strings.Contains(strings.ToUpper(stmt), "NOT VALID") && !strings.Contains(strings.ToUpper(stmt), "USING INDEX") {
+				invalid("adding constraint scans the table while holding exclusive lock, add it as NOT VALID and validate it separately: %s", stmt)
+			}
+
+			if rawAlterType.MatchString(stmt) {
+				invalid("changing column type rewrites the table while holding exclusive lock, add a new column and backfill it instead: %s", stmt)
+			}
+
+			if match := rawNotNullCheck.FindStringSubmatch(stmt); match != nil {
+				checks[table+"."+match[1]] = match[2]
+			}
+
+			if match := rawValidate.FindStringSubmatch(stmt); match != nil {
+				if column, ok := checks[table+"."+match[1]]; ok {
+					validated[table+"."+column] = true
+				}
+
+				if !migration.DisableTransaction {
+					invalid("validating constraint inside transaction holds the exclusive lock taken by earlier statements until the scan finishes, register the migration with DisableTransaction: %s", stmt)
+				}
+			}
+
+			for _, match := range rawSetNotNull.FindAllStringSubmatch(stmt, -1) {
+				if !created[table] && !validated[table+"."+match[1]] {
+					invalid("setting not null scans the table while holding exclusive lock, use AddNotNull instead: %s", stmt)
+				}
+			}
+
+			if rawConcurrent.MatchString(stmt) && !migration.DisableTransaction {
+				invalid("statement can't be applied inside transaction, register the migration with DisableTransaction: %s", stmt)
+			}
+		}
+	}
+
+	return errs
+}
+
+func lintAlterTable(table rel.Table, invalid func(format string, args ...interface{})) {
+	for _, definition := range table.Definitions {
+		switch def := definition.(type) {
+		case rel.Column:
+			switch def.Op {
+			case rel.SchemaCreate:
+				if def.Required && def.Default == nil {
+					invalid("adding required column %s.%s without default fails on existing rows, add it as optional column, backfill it and use AddNotNull", table.Name, def.Name)
+				}
+			case rel.SchemaRename:
+				invalid("renaming column %s.%s breaks running application, add a new column and backfill it instead", table.Name, def.Name)
+			case rel.SchemaAlter:
+				invalid("altering column %s.%s rewrites the table while holding exclusive lock, add a new column and backfill it instead", table.Name, def.Name)
+			}
+		case rel.Key:
+			if def.Op == rel.SchemaCreate && def.Type != rel.PrimaryKey {
+				invalid("adding %s on %s scans the table while holding exclusive lock, add it as NOT VALID constraint and validate it separately", def.Type, table.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package migrations
+
+import (
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLint_migrations rejects dangerous operation in every registered migration.
+func TestLint_migrations(t *testing.T) {
+	for _, migration := range Migrations {
+		assert.Empty(t, Lint(migration), "migration %d_%s", migration.Version, migration.Name)
+	}
+}
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name               string
+		migrate            func(schema *rel.Schema)
+		disableTransaction bool
+		errs               []string
+	}{
+		{
+			name: "create table with index",
+			migrate: func(schema *rel.Schema) {
+				schema.CreateTable("tags", func(t *rel.Table) {
+					t.ID("id")
+					t.String("name", rel.Required(true))
+				})
+				schema.CreateIndex("tags", "tags_name", []string{"name"})
+				schema.Exec(`CREATE INDEX tags_id ON tags (id);`)
+			},
+		},
+		{
+			name: "create index on existing table",
+			migrate: func(schema *rel.Schema) {
+				schema.CreateIndex("todos", "todos_title", []string{"title"})
+				schema.Exec(`CREATE UNIQUE INDEX todos_order ON "todos" ("order");`)
+			},
+			errs: []string{
+				"1_test: creating index todos_title blocks writes to todos, use CreateIndexConcurrently instead",
+				"1_test: creating index blocks writes to todos, use CreateIndexConcurrently instead",
+			},
+		},
+		{
+			name: "create index concurrently",
+			migrate: func(schema *rel.Schema) {
+				CreateIndexConcurrently(schema, "todos", "todos_title", []string{"title"})
+			},
+			disableTransaction: true,
+		},
+		{
+			name: "create index concurrently inside transaction",
+			migrate: func(schema *rel.Schema) {
+				DropIndexConcurrently(schema, "todos_title")
+			},
+			errs: []string{
+				`1_test: statement can't be applied inside transaction, register the migration with DisableTransaction: DROP INDEX CONCURRENTLY IF EXISTS "todos_title";`,
+			},
+		},
+		{
+			name: "add column",
+			migrate: func(schema *rel.Schema) {
+				schema.AddColumn("todos", "note", rel.String)
+				schema.AddColumn("todos", "priority", rel.Int, rel.Required(true), rel.Default(0))
+				schema.AddColumn("todos", "owner", rel.String, rel.Required(true))
+			},
+			errs: []string{
+				"1_test: adding required column todos.owner without default fails on existing rows, add it as optional column, backfill it and use AddNotNull",
+			},
+		},
+		{
+			name: "rename and alter",
+			migrate: func(schema *rel.Schema) {
+				schema.RenameTable("todos", "tasks")
+				schema.RenameColumn("todos", "title", "name")
+				schema.AlterTable("points", func(t *rel.AlterTable) {
+					t.ForeignKey("todo_id", "todos", "id")
+				})
+				schema.Exec(`ALTER TABLE todos ALTER COLUMN "order" TYPE bigint;`)
+			},
+			errs: []string{
+				"1_test: renaming table todos breaks running application, create a new table and backfill it instead",
+				"1_test: renaming column todos.title breaks running application, add a new column and backfill it instead",
+				"1_test: adding FOREIGN KEY on points scans the table while holding exclusive lock, add it as NOT VALID constraint and validate it separately",
+				`1_test: changing column type rewrites the table while holding exclusive lock, add a new column and backfill it instead: ALTER TABLE todos ALTER COLUMN "order" TYPE bigint;`,
+			},
+		},
+		{
+			name: "set not null",
+			migrate: func(schema *rel.Schema) {
+				schema.Exec(`ALTER TABLE todos ALTER COLUMN title SET NOT NULL;`)
+				schema.Exec(`ALTER TABLE todos ADD CONSTRAINT todos_order_positive CHECK ("order" >= 0);`)
+			},
+			errs: []string{
+				"1_test: setting not null scans the table while holding exclusive lock, use AddNotNull instead: ALTER TABLE todos ALTER COLUMN title SET NOT NULL;",
+				`1_test: adding constraint scans the table while holding exclusive lock, add it as NOT VALID and validate it separately: ALTER TABLE todos ADD CONSTRAINT todos_order_positive CHECK ("order" >= 0);`,
+			},
+		},
+		{
+			name: "add not null",
+			migrate: func(schema *rel.Schema) {
+				Backfill(schema, "todos", "title = ''", "title IS NULL", 100)
+				AddNotNull(schema, "todos", "title")
+			},
+			disableTransaction: true,
+		},
+		{
+			name: "add not null inside transaction",
+			migrate: func(schema *rel.Schema) {
+				AddNotNull(schema, "todos", "title")
+			},
+			errs: []string{
+				`1_test: validating constraint inside transaction holds the exclusive lock taken by earlier statements until the scan finishes, register the migration with DisableTransaction: ALTER TABLE "todos" VALIDATE CONSTRAINT "todos_title_not_null";`,
+			},
+		},
+		{
+			name: "set not null on other column",
+			migrate: func(schema *rel.Schema) {
+				AddNotNull(schema, "todos", "title")
+				schema.Exec(`ALTER TABLE todos ALTER COLUMN "order" SET NOT NULL;`)
+				schema.Exec(`ALTER TABLE points ALTER COLUMN title SET NOT NULL;`)
+			},
+			disableTransaction: true,
+			errs: []string{
+				`1_test: setting not null scans the table while holding exclusive lock, use AddNotNull instead: ALTER TABLE todos ALTER COLUMN "order" SET NOT NULL;`,
+				"1_test: setting not null scans the table while holding exclusive lock, use AddNotNull instead: ALTER TABLE points ALTER COLUMN title SET NOT NULL;",
+			},
+		},
+		{
+			name: "set not null after validating other constraint",
+			migrate: func(schema *rel.Schema) {
+				schema.Exec(`ALTER TABLE todos ADD CONSTRAINT todos_order_positive CHECK ("order" >= 0) NOT VALID;`)
+				schema.Exec(`ALTER TABLE todos VALIDATE CONSTRAINT todos_order_positive;`)
+				schema.Exec(`ALTER TABLE todos ALTER COLUMN "order" SET NOT NULL;`)
+			},
+			disableTransaction: true,
+			errs: []string{
+				`1_test: setting not null scans the table while holding exclusive lock, use AddNotNull instead: ALTER TABLE todos ALTER COLUMN "order" SET NOT NULL;`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				errs []string
+			)
+
+			for _, err := range Lint(Migration{Version: 1, Name: "test", Migrate: test.migrate, DisableTransaction: test.disableTransaction}) {
+				errs = append(errs, err.Error())
+			}
+
+			assert.Equal(t, test.errs, errs)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-rel/rel"
+)
+
+// ErrBackfillInTransaction returned by Backfill when it's applied inside a transaction, every batch would be committed at once
+// and updated rows stay locked until the whole backfill finishes.
+var ErrBackfillInTransaction = errors.New("migrations: backfill can't be applied inside transaction, register the migration with DisableTransaction")
+
+type transactionKey struct{}
+
+// InTransaction marks context used to apply migration inside a transaction, so Backfill can refuse to run.
+func InTransaction(ctx context.Context) context.Context {
+	return context.WithValue(ctx, transactionKey{}, true)
+}
+
+func inTransaction(ctx context.Context) bool {
+	marked, _ := ctx.Value(transactionKey{}).(bool)
+	return marked
+}
+
+// Migration definition with its version.
+// DisableTransaction must be set when migration uses concurrent operation or Backfill, so each statement is committed separately.
+type Migration struct {
+	Version            int
+	Name               string
+	Migrate            func(schema *rel.Schema)
+	Rollback           func(schema *rel.Schema)
+	DisableTransaction bool
+}
+
+// Migrations list used when migrating from code (eg: dev mode), ordered by version.
+// rel cli discovers migration by its filename, every new migration file should also be registered here.
+var Migrations = []Migration{
+	{Version: 20202806225100, Name: "create_todos", Migrate: MigrateCreateTodos, Rollback: RollbackCreateTodos},
+	{Version: 20203006230600, Name: "create_scores", Migrate: MigrateCreateScores, Rollback: RollbackCreateScores},
+	{Version: 20203006230700, Name: "create_points", Migrate: MigrateCreatePoints, Rollback: RollbackCreatePoints},
+	{Version: 20261710090000, Name: "create_maintenances", Migrate: MigrateCreateMaintenances, Rollback: RollbackCreateMaintenances},
+	{Version: 20261710100000, Name: "create_views", Migrate: MigrateCreateViews, Rollback: RollbackCreateViews},
+	{Version: 20261710110000, Name: "add_read_only_to_maintenances", Migrate: MigrateAddReadOnlyToMaintenances, Rollback: RollbackAddReadOnlyToMaintenances},
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var filename = regexp.MustCompile(`^(\d+)_([a-z0-9_\-]+)\.go$`)
+
+func TestMigrations(t *testing.T) {
+	var (
+		files     []int
+		versions  []int
+		names     = make(map[int]string)
+		lastIndex = len(Migrations) - 1
+	)
+
+	entries, err := os.ReadDir(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range entries {
+		if match := filename.FindStringSubmatch(entry.Name()); match != nil {
+			version, _ := strconv.Atoi(match[1])
+			files = append(files, version)
+			names[version] = match[2]
+		}
+	}
+
+	for i, migration := range Migrations {
+		versions = append(versions, migration.Version)
+		assert.Equal(t, names[migration.Version], migration.Name)
+		assert.NotNil(t, migration.Migrate)
+		assert.NotNil(t, migration.Rollback)
+
+		if i < lastIndex {
+			assert.Less(t, migration.Version, Migrations[i+1].Version, "migrations must be ordered by version")
+		}
+	}
+
+	sort.Ints(files)
+	assert.Equal(t, files, versions, "every migration file must be registered")
+}
@@ -0,0 +1,136 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "migrations")))
+	// DefaultBatchSize used by Backfill when batch size is not positive.
+	DefaultBatchSize = 1000
+)
+
+// Helpers in this file perform schema changes without holding lock that blocks reads or writes for the whole duration of the change.
+// Postgres doesn't allow some of these operations inside a transaction, migration using them must be registered with DisableTransaction.
+//
+// A breaking change should be split into expand and contract migrations deployed separately:
+//  1. expand: add the new column as nullable, deploy code that writes to both columns.
+//  2. backfill: copy existing rows using Backfill, then make it required using AddNotNull.
+//  3. contract: deploy code that only uses the new column, then drop the old column.
+
+// CreateIndexConcurrently creates index without blocking writes to the table.
+// A failed concurrent build leaves an INVALID index behind, it's dropped first so retrying the migration rebuilds it.
+func CreateIndexConcurrently(schema *rel.Schema, table string, name string, columns []string) {
+	dropInvalidIndex(schema, name)
+	schema.Exec(rel.Raw(fmt.Sprintf("CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s (%s);", quote(name), quote(table), quoteAll(columns))))
+}
+
+// CreateUniqueIndexConcurrently creates unique index without blocking writes to the table.
+// Like CreateIndexConcurrently, INVALID index left by a failed build is dropped first.
+func CreateUniqueIndexConcurrently(schema *rel.Schema, table string, name string, columns []string) {
+	dropInvalidIndex(schema, name)
+	schema.Exec(rel.Raw(fmt.Sprintf("CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s (%s);", quote(name), quote(table), quoteAll(columns))))
+}
+
+// DropIndexConcurrently drops index without blocking reads and writes to the table.
+func DropIndexConcurrently(schema *rel.Schema, name string) {
+	schema.Exec(rel.Raw(fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s;", quote(name))))
+}
+
+// AddNotNull makes existing column required without scanning the table while holding exclusive lock.
+// The column is validated using a NOT VALID check constraint first, postgres (12+) then uses the validated constraint to skip the scan when setting NOT NULL.
+// Every null value must be backfilled before running this migration.
+// The constraint left by a failed attempt is dropped first, so the migration can be retried.
+func AddNotNull(schema *rel.Schema, table string, column string) {
+	var (
+		constraint = quote(table + "_" + column + "_not_null")
+		name       = quote(table)
+	)
+
+	schema.Exec(rel.Raw(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", name, constraint)))
+	schema.Exec(rel.Raw(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s IS NOT NULL) NOT VALID;", name, constraint, quote(column))))
+	schema.Exec(rel.Raw(fmt.Sprintf("ALTER TABLE %s VALIDATE CONSTRAINT %s;", name, constraint)))
+	schema.Exec(rel.Raw(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", name, quote(column))))
+	schema.Exec(rel.Raw(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;", name, constraint)))
+}
+
+// RemoveNotNull makes column optional, used to rollback AddNotNull.
+func RemoveNotNull(schema *rel.Schema, table string, column string) {
+	schema.Exec(rel.Raw(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", quote(table), quote(column))))
+}
+
+// Backfill updates rows matching filter in batches, so rows are only locked for the duration of a single batch.
+// Filter must exclude rows that are already updated (eg: "new_column IS NULL"), otherwise backfill never ends.
+// Set and filter are raw sql fragments, progress is logged after every batch.
+// Lint can't inspect the backfill, it returns ErrBackfillInTransaction instead when applied inside a transaction.
+func Backfill(schema *rel.Schema, table string, set string, filter string, batchSize int) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	schema.Do(func(ctx context.Context, repository rel.Repository) error {
+		if inTransaction(ctx) {
+			return ErrBackfillInTransaction
+		}
+
+		var (
+			name = quote(table)
+			stmt = fmt.Sprintf("UPDATE %s SET %s WHERE id IN (SELECT id FROM %s WHERE %s LIMIT %d);", name, set, name, filter, batchSize)
+			done int
+		)
+
+		pending, err := repository.Count(ctx, table, rel.FilterFragment(filter))
+		if err != nil {
+			return err
+		}
+
+		logger.Info("backfill started", zap.String("table", table), zap.Int("pending", pending))
+
+		for {
+			_, updated, err := repository.Exec(ctx, stmt)
+			if err != nil {
+				return err
+			}
+
+			if updated == 0 {
+				logger.Info("backfill finished", zap.String("table", table), zap.Int("updated", done))
+				return nil
+			}
+
+			done += updated
+			logger.Info("backfill progress", zap.String("table", table), zap.Int("updated", done), zap.Int("pending", pending))
+		}
+	})
+}
+
+// dropInvalidIndex drops index that is marked INVALID by a failed concurrent build, CREATE INDEX IF NOT EXISTS would skip it otherwise.
+func dropInvalidIndex(schema *rel.Schema, name string) {
+	schema.Do(func(ctx context.Context, repository rel.Repository) error {
+		invalid, err := repository.Count(ctx, "pg_index", rel.FilterFragment("indexrelid = to_regclass(?) AND NOT indisvalid", quote(name)))
+		if err != nil || invalid == 0 {
+			return err
+		}
+
+		logger.Warn("dropping invalid index", zap.String("index", name))
+		_, _, err = repository.Exec(ctx, fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s;", quote(name)))
+		return err
+	})
+}
+
+func quote(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func quoteAll(names []string) string {
+	quoted := make([]string, len(names))
+	for i := range names {
+		quoted[i] = quote(names[i])
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,135 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateIndexConcurrently(t *testing.T) {
+	var schema rel.Schema
+
+	CreateIndexConcurrently(&schema, "todos", "todos_title", []string{"title", "order"})
+	CreateUniqueIndexConcurrently(&schema, "todos", "todos_order", []string{"order"})
+	DropIndexConcurrently(&schema, "todos_title")
+
+	assert.Len(t, schema.Migrations, 5)
+	assert.IsType(t, rel.Do(nil), schema.Migrations[0])
+	assert.Equal(t, rel.Raw(`CREATE INDEX CONCURRENTLY IF NOT EXISTS "todos_title" ON "todos" ("title", "order");`), schema.Migrations[1])
+	assert.IsType(t, rel.Do(nil), schema.Migrations[2])
+	assert.Equal(t, rel.Raw(`CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS "todos_order" ON "todos" ("order");`), schema.Migrations[3])
+	assert.Equal(t, rel.Raw(`DROP INDEX CONCURRENTLY IF EXISTS "todos_title";`), schema.Migrations[4])
+}
+
+func TestCreateIndexConcurrently_invalidIndex(t *testing.T) {
+	var (
+		ctx    = context.TODO()
+		filter = rel.FilterFragment("indexrelid = to_regclass(?) AND NOT indisvalid", `"todos_title"`)
+		noArgs []interface{}
+	)
+
+	tests := []struct {
+		name    string
+		invalid int
+		drop    bool
+	}{
+		{
+			name:    "invalid",
+			invalid: 1,
+			drop:    true,
+		},
+		{
+			name:    "valid or missing",
+			invalid: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				schema     rel.Schema
+				repository = reltest.New()
+			)
+
+			CreateIndexConcurrently(&schema, "todos", "todos_title", []string{"title"})
+
+			repository.ExpectCount("pg_index", filter).Result(test.invalid)
+			if test.drop {
+				repository.ExpectExec(`DROP INDEX CONCURRENTLY IF EXISTS "todos_title";`, noArgs).Result(0, 0)
+			}
+
+			assert.Nil(t, schema.Migrations[0].(rel.Do)(ctx, repository))
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAddNotNull(t *testing.T) {
+	var schema rel.Schema
+
+	AddNotNull(&schema, "todos", "title")
+	RemoveNotNull(&schema, "todos", "title")
+
+	assert.Equal(t, []rel.Migration{
+		rel.Raw(`ALTER TABLE "todos" DROP CONSTRAINT IF EXISTS "todos_title_not_null";`),
+		rel.Raw(`ALTER TABLE "todos" ADD CONSTRAINT "todos_title_not_null" CHECK ("title" IS NOT NULL) NOT VALID;`),
+		rel.Raw(`ALTER TABLE "todos" VALIDATE CONSTRAINT "todos_title_not_null";`),
+		rel.Raw(`ALTER TABLE "todos" ALTER COLUMN "title" SET NOT NULL;`),
+		rel.Raw(`ALTER TABLE "todos" DROP CONSTRAINT IF EXISTS "todos_title_not_null";`),
+		rel.Raw(`ALTER TABLE "todos" ALTER COLUMN "title" DROP NOT NULL;`),
+	}, schema.Migrations)
+}
+
+func TestBackfill(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		schema     rel.Schema
+		repository = reltest.New()
+		stmt       = `UPDATE "todos" SET title = 'untitled' WHERE id IN (SELECT id FROM "todos" WHERE title IS NULL LIMIT 2);`
+		// reltest passes exec args as a single slice argument.
+		noArgs []interface{}
+	)
+
+	Backfill(&schema, "todos", "title = 'untitled'", "title IS NULL", 2)
+
+	repository.ExpectCount("todos", rel.FilterFragment("title IS NULL")).Result(3)
+	repository.ExpectExec(stmt, noArgs).Result(0, 2)
+	repository.ExpectExec(stmt, noArgs).Result(0, 1)
+	repository.ExpectExec(stmt, noArgs).Result(0, 0)
+
+	assert.Len(t, schema.Migrations, 1)
+	assert.Nil(t, schema.Migrations[0].(rel.Do)(ctx, repository))
+	repository.AssertExpectations(t)
+}
+
+func TestBackfill_inTransaction(t *testing.T) {
+	var (
+		ctx        = InTransaction(context.TODO())
+		schema     rel.Schema
+		repository = reltest.New()
+	)
+
+	Backfill(&schema, "todos", "title = 'untitled'", "title IS NULL", 0)
+
+	assert.Equal(t, ErrBackfillInTransaction, schema.Migrations[0].(rel.Do)(ctx, repository))
+	repository.AssertExpectations(t)
+}
+
+func TestBackfill_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		schema     rel.Schema
+		repository = reltest.New()
+	)
+
+	Backfill(&schema, "todos", "title = 'untitled'", "title IS NULL", 0)
+
+	repository.ExpectCount("todos", rel.FilterFragment("title IS NULL")).Result(3)
+	repository.ExpectExec(`UPDATE "todos" SET title = 'untitled' WHERE id IN (SELECT id FROM "todos" WHERE title IS NULL LIMIT 1000);`, []interface{}(nil)).ConnectionClosed()
+
+	assert.Equal(t, reltest.ErrConnectionClosed, schema.Migrations[0].(rel.Do)(ctx, repository))
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+
+	"github.com/Fs02/go-todo-backend/factories"
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+)
+
+// Seed database with demo data, nothing will be seeded when todos table is not empty.
+func Seed(ctx context.Context, repository rel.Repository) error {
+	count, err := repository.Count(ctx, "todos")
+	if err != nil || count > 0 {
+		return err
+	}
+
+	logger.Info("seeding demo data")
+
+	// models are built using factories and inserted here instead of factories.Create*,
+	// so a failed insert rolls back the transaction and is returned instead of panicking.
+	return repository.Transaction(ctx, func(ctx context.Context) error {
+		var (
+			completed = []string{"Wake up", "Make coffee"}
+			titles    = []string{"Write some code", "Sleep"}
+			score     = factories.Score(func(score *scores.Score) {
+				score.TotalPoint = len(completed)
+			})
+		)
+
+		if err := repository.Insert(ctx, &score); err != nil {
+			return err
+		}
+
+		for i, title := range completed {
+			var (
+				todo = factories.Todo(func(todo *todos.Todo) {
+					todo.Title = title
+					todo.Order = i
+					todo.Completed = true
+				})
+				point = factories.Point(func(point *scores.Point) {
+					point.ScoreID = score.ID
+				})
+			)
+
+			if err := repository.Insert(ctx, &todo); err != nil {
+				return err
+			}
+
+			if err := repository.Insert(ctx, &point); err != nil {
+				return err
+			}
+		}
+
+		for i, title := range titles {
+			todo := factories.Todo(func(todo *todos.Todo) {
+				todo.Title = title
+				todo.Order = len(completed) + i
+			})
+
+			if err := repository.Insert(ctx, &todo); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeed(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectCount("todos").Result(0)
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&scores.Score{TotalPoint: 2})
+		repository.ExpectInsert().For(&todos.Todo{Title: "Wake up", Order: 0, Completed: true})
+		repository.ExpectInsert().For(&scores.Point{Name: "todo completed", Count: 1, ScoreID: 1})
+		repository.ExpectInsert().For(&todos.Todo{Title: "Make coffee", Order: 1, Completed: true})
+		repository.ExpectInsert().For(&scores.Point{Name: "todo completed", Count: 1, ScoreID: 1})
+		repository.ExpectInsert().For(&todos.Todo{Title: "Write some code", Order: 2})
+		repository.ExpectInsert().For(&todos.Todo{Title: "Sleep", Order: 3})
+	})
+
+	assert.Nil(t, Seed(ctx, repository))
+	repository.AssertExpectations(t)
+}
+
+func TestSeed_insertError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectCount("todos").Result(0)
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectInsert().For(&scores.Score{TotalPoint: 2})
+		repository.ExpectInsert().For(&todos.Todo{Title: "Wake up", Order: 0, Completed: true}).ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, Seed(ctx, repository))
+	repository.AssertExpectations(t)
+}
+
+func TestSeed_notEmpty(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectCount("todos").Result(1)
+
+	assert.Nil(t, Seed(ctx, repository))
+	repository.AssertExpectations(t)
+}
+
+func TestSeed_countError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectCount("todos").ConnectionClosed()
+
+	assert.Equal(t, reltest.ErrConnectionClosed, Seed(ctx, repository))
+	repository.AssertExpectations(t)
+}
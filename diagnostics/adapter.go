@@ -0,0 +1,98 @@
+package diagnostics
+
+import (
+	"context"
+
+	"github.com/go-rel/postgres"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/sql"
+)
+
+// Adapter samples read queries executed through the wrapped adapter, including queries inside transaction.
+// Every query is also counted in the Stats tracked by the context, see Track.
+type Adapter struct {
+	rel.Adapter
+	builder sql.QueryBuilder
+	service Service
+}
+
+// Query samples and performs query.
+func (a Adapter) Query(ctx context.Context, query rel.Query) (rel.Cursor, error) {
+	defer observe(ctx)()
+
+	if a.service.Sampled() {
+		statement, args := a.builder.Build(query)
+		a.service.Sample(ctx, statement, args)
+	}
+
+	return a.Adapter.Query(ctx, query)
+}
+
+// Aggregate samples and performs aggregate query.
+func (a Adapter) Aggregate(ctx context.Context, query rel.Query, mode string, field string) (int, error) {
+	defer observe(ctx)()
+
+	if a.service.Sampled() {
+		// build the same statement built by sql adapter.
+		aggregateQuery := query.Select(append([]string{"^" + mode + "(" + field + ") AS result"}, query.GroupQuery.Fields...)...)
+		statement, args := a.builder.Build(aggregateQuery)
+		a.service.Sample(ctx, statement, args)
+	}
+
+	return a.Adapter.Aggregate(ctx, query, mode, field)
+}
+
+// Insert performs insert query.
+func (a Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (any, error) {
+	defer observe(ctx)()
+	return a.Adapter.Insert(ctx, query, primaryField, mutates, onConflict)
+}
+
+// InsertAll performs bulk insert query.
+func (a Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) ([]any, error) {
+	defer observe(ctx)()
+	return a.Adapter.InsertAll(ctx, query, primaryField, fields, bulkMutates, onConflict)
+}
+
+// Update performs update query.
+func (a Adapter) Update(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate) (int, error) {
+	defer observe(ctx)()
+	return a.Adapter.Update(ctx, query, primaryField, mutates)
+}
+
+// Delete performs delete query.
+func (a Adapter) Delete(ctx context.Context, query rel.Query) (int, error) {
+	defer observe(ctx)()
+	return a.Adapter.Delete(ctx, query)
+}
+
+// Exec performs raw statement.
+func (a Adapter) Exec(ctx context.Context, stmt string, args []any) (int64, int64, error) {
+	defer observe(ctx)()
+	return a.Adapter.Exec(ctx, stmt, args)
+}
+
+// Begin transaction, the transaction adapter is also sampled.
+func (a Adapter) Begin(ctx context.Context) (rel.Adapter, error) {
+	adapter, err := a.Adapter.Begin(ctx)
+	if err != nil {
+		return adapter, err
+	}
+
+	a.Adapter = adapter
+	return a, nil
+}
+
+// Wrap postgres adapter so its queries are sampled by service, other adapter is returned as is.
+func Wrap(adapter rel.Adapter, service Service) rel.Adapter {
+	pg, ok := adapter.(*postgres.Postgres)
+	if !ok {
+		return adapter
+	}
+
+	return Adapter{
+		Adapter: adapter,
+		builder: pg.QueryBuilder,
+		service: service,
+	}
+}
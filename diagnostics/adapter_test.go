@@ -0,0 +1,122 @@
+package diagnostics_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/diagnostics"
+	"github.com/Fs02/go-todo-backend/diagnostics/diagnosticstest"
+	"github.com/go-rel/postgres"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/stretchr/testify/assert"
+)
+
+// adapter returns result without touching database.
+type adapter struct {
+	rel.Adapter
+}
+
+func (a adapter) Query(ctx context.Context, query rel.Query) (rel.Cursor, error) {
+	return nil, nil
+}
+
+func (a adapter) Aggregate(ctx context.Context, query rel.Query, mode string, field string) (int, error) {
+	return 1, nil
+}
+
+func (a adapter) Exec(ctx context.Context, stmt string, args []any) (int64, int64, error) {
+	return 0, 1, nil
+}
+
+func (a adapter) Delete(ctx context.Context, query rel.Query) (int, error) {
+	return 1, nil
+}
+
+func (a adapter) Begin(ctx context.Context) (rel.Adapter, error) {
+	return a, nil
+}
+
+func TestWrap(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		pg      = postgres.New(nil).(*postgres.Postgres)
+		service = &diagnosticstest.Service{}
+		wrapped = diagnostics.Wrap(pg, service).(diagnostics.Adapter)
+	)
+
+	// replace embedded adapter, so query is never sent to database.
+	wrapped.Adapter = adapter{Adapter: pg}
+
+	diagnosticstest.Mock(service,
+		diagnosticstest.MockSampled(true),
+		diagnosticstest.MockSample(`SELECT "todos".* FROM "todos" WHERE "todos"."id"=$1;`, []interface{}{1}),
+		diagnosticstest.MockSample(`SELECT count(*) AS result FROM "todos";`, []interface{}(nil)),
+	)
+
+	tx, err := wrapped.Begin(ctx)
+	assert.Nil(t, err)
+
+	_, err = tx.Query(ctx, rel.From("todos").Where(where.Eq("id", 1)))
+	assert.Nil(t, err)
+
+	count, err := tx.Aggregate(ctx, rel.From("todos"), "count", "*")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, count)
+
+	service.AssertExpectations(t)
+}
+
+func TestWrap_stats(t *testing.T) {
+	var (
+		ctx, stats = diagnostics.Track(context.TODO())
+		service    = &diagnosticstest.Service{}
+		wrapped    = diagnostics.Wrap(postgres.New(nil), service).(diagnostics.Adapter)
+	)
+
+	wrapped.Adapter = adapter{}
+	diagnosticstest.Mock(service, diagnosticstest.MockSampled(false))
+
+	tx, err := wrapped.Begin(ctx)
+	assert.Nil(t, err)
+
+	_, err = tx.Query(ctx, rel.From("todos"))
+	assert.Nil(t, err)
+
+	_, err = tx.Delete(ctx, rel.From("todos"))
+	assert.Nil(t, err)
+
+	_, _, err = tx.Exec(ctx, "SELECT 1;", nil)
+	assert.Nil(t, err)
+
+	// untracked context is not counted.
+	_, _, err = tx.Exec(context.TODO(), "SELECT 1;", nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 3, stats.Queries())
+	service.AssertExpectations(t)
+}
+
+func TestWrap_notSampled(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		service = &diagnosticstest.Service{}
+		wrapped = diagnostics.Wrap(postgres.New(nil), service).(diagnostics.Adapter)
+	)
+
+	wrapped.Adapter = adapter{}
+	diagnosticstest.Mock(service, diagnosticstest.MockSampled(false))
+
+	_, err := wrapped.Query(ctx, rel.From("todos"))
+	assert.Nil(t, err)
+
+	service.AssertExpectations(t)
+}
+
+func TestWrap_otherAdapter(t *testing.T) {
+	var (
+		other = adapter{}
+	)
+
+	assert.Equal(t, other, diagnostics.Wrap(other, &diagnosticstest.Service{}))
+}
@@ -0,0 +1,39 @@
+package diagnosticstest
+
+import (
+	diagnostics "github.com/Fs02/go-todo-backend/diagnostics"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFunc function.
+type MockFunc func(service *Service)
+
+// Mock apply mock diagnostics functions.
+func Mock(service *Service, funcs ...MockFunc) {
+	for i := range funcs {
+		if funcs[i] != nil {
+			funcs[i](service)
+		}
+	}
+}
+
+// MockSampled util.
+func MockSampled(sampled bool) MockFunc {
+	return func(service *Service) {
+		service.On("Sampled").Return(sampled)
+	}
+}
+
+// MockSample util.
+func MockSample(statement string, args []interface{}) MockFunc {
+	return func(service *Service) {
+		service.On("Sample", mock.Anything, statement, args)
+	}
+}
+
+// MockReport util.
+func MockReport(limit int, result diagnostics.Report) MockFunc {
+	return func(service *Service) {
+		service.On("Report", limit).Return(result)
+	}
+}
@@ -0,0 +1,68 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Insight summarizes query plans sampled from the same route and statement.
+type Insight struct {
+	Route       string          `json:"route"`
+	Statement   string          `json:"statement"`
+	Samples     int             `json:"samples"`
+	Cost        float64         `json:"cost"`
+	Rows        float64         `json:"rows"`
+	SeqScans    []string        `json:"seq_scans"`
+	Plan        json.RawMessage `json:"plan"`
+	ExplainedAt time.Time       `json:"explained_at"`
+}
+
+// Report of sampled queries, insights are ordered from the most expensive one.
+type Report struct {
+	Rate      float64   `json:"rate"`
+	Sampled   int       `json:"sampled"`
+	Explained int       `json:"explained"`
+	Dropped   int       `json:"dropped"`
+	Failed    int       `json:"failed"`
+	Queries   []Insight `json:"queries"`
+}
+
+// plan node of postgres EXPLAIN (FORMAT JSON) output.
+type plan struct {
+	NodeType     string  `json:"Node Type"`
+	RelationName string  `json:"Relation Name"`
+	TotalCost    float64 `json:"Total Cost"`
+	PlanRows     float64 `json:"Plan Rows"`
+	Plans        []plan  `json:"Plans"`
+}
+
+// seqScans returns every relation scanned sequentially by the plan and its children.
+func (p plan) seqScans() []string {
+	var (
+		relations []string
+	)
+
+	if p.NodeType == "Seq Scan" {
+		relations = append(relations, p.RelationName)
+	}
+
+	for i := range p.Plans {
+		relations = append(relations, p.Plans[i].seqScans()...)
+	}
+
+	return relations
+}
+
+func parse(output []byte) (plan, error) {
+	var (
+		explained []struct {
+			Plan plan `json:"Plan"`
+		}
+	)
+
+	if err := json.Unmarshal(output, &explained); err != nil || len(explained) == 0 {
+		return plan{}, errInvalidPlan
+	}
+
+	return explained[0].Plan, nil
+}
@@ -0,0 +1,28 @@
+package diagnostics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const seqScanPlan = `[{"Plan": {"Node Type": "Sort", "Total Cost": 180.5, "Plan Rows": 40, "Plans": [
+	{"Node Type": "Seq Scan", "Relation Name": "todos", "Total Cost": 170.2, "Plan Rows": 40}
+]}}]`
+
+func TestParse(t *testing.T) {
+	p, err := parse([]byte(seqScanPlan))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 180.5, p.TotalCost)
+	assert.Equal(t, float64(40), p.PlanRows)
+	assert.Equal(t, []string{"todos"}, p.seqScans())
+}
+
+func TestParse_invalid(t *testing.T) {
+	_, err := parse([]byte(`[]`))
+	assert.Equal(t, errInvalidPlan, err)
+
+	_, err = parse([]byte(`{`))
+	assert.Equal(t, errInvalidPlan, err)
+}
@@ -0,0 +1,190 @@
+package diagnostics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "diagnostics")))
+	// QueueSize of sampled queries waiting to be explained, samples are dropped when the queue is full.
+	QueueSize = 100
+	// MaxInsights kept in memory, samples of new statement are dropped once reached.
+	MaxInsights = 1000
+	// ExplainTimeout for each sampled query.
+	ExplainTimeout = 5 * time.Second
+
+	errInvalidPlan = errors.New("diagnostics: invalid plan")
+)
+
+//go:generate mockery --name=Service --case=underscore --output diagnosticstest --outpkg diagnosticstest
+
+// Service samples repository queries and explains it in background.
+type Service interface {
+	Sampled() bool
+	Sample(ctx context.Context, statement string, args []interface{})
+	Report(limit int) Report
+}
+
+// Explainer returns EXPLAIN (FORMAT JSON) output of a statement.
+type Explainer func(ctx context.Context, statement string, args []interface{}) ([]byte, error)
+
+// NewExplainer using database connection, explained statement is planned but never executed.
+func NewExplainer(database *sql.DB) Explainer {
+	return func(ctx context.Context, statement string, args []interface{}) ([]byte, error) {
+		var output []byte
+		err := database.QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+statement, args...).Scan(&output)
+		return output, err
+	}
+}
+
+type key struct {
+	route     string
+	statement string
+}
+
+type sample struct {
+	key
+	args []interface{}
+}
+
+type service struct {
+	explain  Explainer
+	rate     float64
+	queue    chan sample
+	lock     sync.Mutex
+	report   Report
+	insights map[key]*Insight
+}
+
+var _ Service = (*service)(nil)
+
+// Sampled decides whether the next query should be sampled.
+func (s *service) Sampled() bool {
+	return s.rate > 0 && rand.Float64() < s.rate
+}
+
+// Sample queues statement to be explained, route is taken from the request context.
+func (s *service) Sample(ctx context.Context, statement string, args []interface{}) {
+	route := "background"
+	if rctx := chi.RouteContext(ctx); rctx != nil {
+		route = rctx.RouteMethod + " " + rctx.RoutePattern()
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	select {
+	case s.queue <- sample{key: key{route: route, statement: statement}, args: args}:
+		s.report.Sampled++
+	default:
+		s.report.Dropped++
+	}
+}
+
+// Report returns the most expensive queries, all queries are returned when limit is not positive.
+func (s *service) Report(limit int) Report {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	report := s.report
+	report.Queries = make([]Insight, 0, len(s.insights))
+	for _, insight := range s.insights {
+		report.Queries = append(report.Queries, *insight)
+	}
+
+	sort.Slice(report.Queries, func(i, j int) bool {
+		return report.Queries[i].Cost > report.Queries[j].Cost
+	})
+
+	if limit > 0 && len(report.Queries) > limit {
+		report.Queries = report.Queries[:limit]
+	}
+
+	return report
+}
+
+func (s *service) work() {
+	for sample := range s.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), ExplainTimeout)
+		output, err := s.explain(ctx, sample.statement, sample.args)
+		cancel()
+
+		var p plan
+		if err == nil {
+			p, err = parse(output)
+		}
+
+		if err != nil {
+			logger.Warn("explain error", zap.Error(err), zap.String("route", sample.route), zap.String("statement", sample.statement))
+			s.record(sample.key, nil, plan{}, err)
+			continue
+		}
+
+		logger.Info("query plan",
+			zap.String("route", sample.route),
+			zap.String("statement", sample.statement),
+			zap.Float64("cost", p.TotalCost),
+			zap.Strings("seq_scans", p.seqScans()))
+
+		s.record(sample.key, output, p, nil)
+	}
+}
+
+func (s *service) record(k key, output []byte, p plan, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err != nil {
+		s.report.Failed++
+		return
+	}
+
+	s.report.Explained++
+
+	insight, ok := s.insights[k]
+	if !ok {
+		if len(s.insights) >= MaxInsights {
+			s.report.Dropped++
+			return
+		}
+
+		insight = &Insight{Route: k.route, Statement: k.statement}
+		s.insights[k] = insight
+	}
+
+	insight.Samples++
+	insight.ExplainedAt = time.Now()
+	if p.TotalCost >= insight.Cost {
+		insight.Cost = p.TotalCost
+		insight.Rows = p.PlanRows
+		insight.SeqScans = p.seqScans()
+		insight.Plan = output
+	}
+}
+
+// New diagnostics service, a fraction of queries given by rate (0 to 1) will be explained in background.
+// Sampling is disabled when rate is not positive.
+func New(explain Explainer, rate float64) Service {
+	s := &service{
+		explain:  explain,
+		rate:     rate,
+		queue:    make(chan sample, QueueSize),
+		insights: make(map[key]*Insight),
+		report:   Report{Rate: rate},
+	}
+
+	if rate > 0 {
+		go s.work()
+	}
+
+	return s
+}
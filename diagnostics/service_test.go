@@ -0,0 +1,81 @@
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/stretchr/testify/assert"
+)
+
+const indexScanPlan = `[{"Plan": {"Node Type": "Index Scan", "Relation Name": "todos", "Total Cost": 8.3, "Plan Rows": 1}}]`
+
+func TestService(t *testing.T) {
+	var (
+		rctx    = chi.NewRouteContext()
+		ctx     = context.WithValue(context.TODO(), chi.RouteCtxKey, rctx)
+		plans   = map[string]string{"SELECT * FROM todos;": seqScanPlan, "SELECT * FROM todos WHERE id=$1;": indexScanPlan}
+		explain = func(ctx context.Context, statement string, args []interface{}) ([]byte, error) {
+			if plan, ok := plans[statement]; ok {
+				return []byte(plan), nil
+			}
+
+			return nil, errors.New("syntax error")
+		}
+		service = New(explain, 1)
+	)
+
+	rctx.RouteMethod = "GET"
+	rctx.RoutePatterns = []string{"/todos/*", "/"}
+
+	assert.True(t, service.Sampled())
+
+	service.Sample(ctx, "SELECT * FROM todos WHERE id=$1;", []interface{}{1})
+	service.Sample(ctx, "SELECT * FROM todos;", nil)
+	service.Sample(ctx, "SELECT * FROM todos;", nil)
+	service.Sample(context.TODO(), "SELECT;", nil)
+
+	assert.Eventually(t, func() bool {
+		report := service.Report(0)
+		return report.Explained+report.Failed == 4
+	}, time.Second, time.Millisecond)
+
+	report := service.Report(1)
+	assert.Equal(t, float64(1), report.Rate)
+	assert.Equal(t, 4, report.Sampled)
+	assert.Equal(t, 3, report.Explained)
+	assert.Equal(t, 1, report.Failed)
+	assert.Len(t, report.Queries, 1)
+	assert.Equal(t, "GET /todos/", report.Queries[0].Route)
+	assert.Equal(t, "SELECT * FROM todos;", report.Queries[0].Statement)
+	assert.Equal(t, 2, report.Queries[0].Samples)
+	assert.Equal(t, 180.5, report.Queries[0].Cost)
+	assert.Equal(t, []string{"todos"}, report.Queries[0].SeqScans)
+	assert.JSONEq(t, seqScanPlan, string(report.Queries[0].Plan))
+
+	assert.Len(t, service.Report(0).Queries, 2)
+}
+
+func TestService_disabled(t *testing.T) {
+	var (
+		service = New(nil, 0)
+	)
+
+	assert.False(t, service.Sampled())
+	assert.Equal(t, Report{Queries: []Insight{}}, service.Report(10))
+}
+
+func TestService_dropped(t *testing.T) {
+	var (
+		svc = &service{queue: make(chan sample, 1), insights: make(map[key]*Insight)}
+	)
+
+	svc.Sample(context.TODO(), "SELECT 1;", nil)
+	svc.Sample(context.TODO(), "SELECT 2;", nil)
+
+	report := svc.Report(0)
+	assert.Equal(t, 1, report.Sampled)
+	assert.Equal(t, 1, report.Dropped)
+}
@@ -0,0 +1,73 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type statsKey struct{}
+
+// Stats of database queries executed using a tracked context, usually a single request.
+type Stats struct {
+	lock     sync.Mutex
+	queries  int
+	duration time.Duration
+}
+
+// Track queries executed through Adapter using the returned context.
+// Stats tracked by the parent context is reused, so nested tracking doesn't split the count.
+func Track(ctx context.Context) (context.Context, *Stats) {
+	if stats, ok := ctx.Value(statsKey{}).(*Stats); ok {
+		return ctx, stats
+	}
+
+	stats := &Stats{}
+	return context.WithValue(ctx, statsKey{}, stats), stats
+}
+
+// Queries executed.
+func (s *Stats) Queries() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.queries
+}
+
+// Duration spent waiting for database.
+func (s *Stats) Duration() time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.duration
+}
+
+// ServerTiming header value of the stats, eg: db;dur=1.25;desc="3 queries".
+func (s *Stats) ServerTiming() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return fmt.Sprintf(`db;dur=%.2f;desc="%d queries"`, float64(s.duration)/float64(time.Millisecond), s.queries)
+}
+
+func (s *Stats) record(duration time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.queries++
+	s.duration += duration
+}
+
+// observe starts timing a query, returned function must be called once the query finished.
+func observe(ctx context.Context) func() {
+	stats, ok := ctx.Value(statsKey{}).(*Stats)
+	if !ok {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		stats.record(time.Since(start))
+	}
+}
@@ -0,0 +1,37 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrack(t *testing.T) {
+	var (
+		ctx, stats  = Track(context.TODO())
+		nested, got = Track(ctx)
+	)
+
+	assert.Equal(t, ctx, nested)
+	assert.Same(t, stats, got)
+
+	observe(ctx)()
+	observe(nested)()
+	observe(context.TODO())()
+
+	assert.Equal(t, 2, stats.Queries())
+	assert.True(t, stats.Duration() >= 0)
+}
+
+func TestStats_ServerTiming(t *testing.T) {
+	var (
+		stats = &Stats{}
+	)
+
+	stats.record(1500 * time.Microsecond)
+	stats.record(250 * time.Microsecond)
+
+	assert.Equal(t, `db;dur=1.75;desc="2 queries"`, stats.ServerTiming())
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+)
+
+// Event is a domain event published by a domain service after something happened to its entity.
+// Name is namespaced by the entity, eg: "todo.completed".
+type Event interface {
+	EventName() string
+}
+
+// Mode of a subscription.
+type Mode int
+
+const (
+	// Sync handler is called when the event is published, inside the publisher's transaction.
+	// Returning error rollbacks the transaction and fails the publisher.
+	Sync Mode = iota
+	// Async handler is called in background after the publisher's transaction is committed.
+	// It's never called when the transaction is rolled back, and returned error is only logged.
+	Async
+)
+
+// String returns name of the mode.
+func (m Mode) String() string {
+	if m == Async {
+		return "async"
+	}
+
+	return "sync"
+}
+
+// Handler of a subscription, use type assertion to get the typed event.
+type Handler func(ctx context.Context, event Event) error
+
+type subscription struct {
+	mode    Mode
+	handler Handler
+}
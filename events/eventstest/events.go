@@ -0,0 +1,44 @@
+package eventstest
+
+import (
+	"context"
+
+	events "github.com/Fs02/go-todo-backend/events"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFunc function.
+type MockFunc func(service *Service)
+
+// Mock apply mock events functions.
+func Mock(service *Service, funcs ...MockFunc) {
+	for i := range funcs {
+		if funcs[i] != nil {
+			funcs[i](service)
+		}
+	}
+}
+
+// MockPublish util, event can be a testify argument matcher such as mock.AnythingOfType.
+func MockPublish(event interface{}, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Publish", mock.Anything, event).Return(err)
+	}
+}
+
+// MockSubscribe util.
+func MockSubscribe(name string, mode events.Mode) MockFunc {
+	return func(service *Service) {
+		service.On("Subscribe", name, mode, mock.Anything)
+	}
+}
+
+// MockTransaction util, fn is called directly without starting repository transaction.
+func MockTransaction() MockFunc {
+	return func(service *Service) {
+		service.On("Transaction", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+	}
+}
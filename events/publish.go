@@ -0,0 +1,30 @@
+package events
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type publish struct {
+	registry *registry
+}
+
+// Publish calls sync handlers of the event immediately using the given context, so it joins the publisher's transaction.
+// Async handlers are deferred until the transaction started using Transaction is committed, or dispatched right away when there's none.
+func (p publish) Publish(ctx context.Context, event Event) error {
+	for _, handler := range p.registry.lookup(event.EventName(), Sync) {
+		if err := handler(ctx, event); err != nil {
+			logger.Warn("sync handler error", zap.String("event", event.EventName()), zap.Error(err))
+			return err
+		}
+	}
+
+	if pending, ok := ctx.Value(pendingKey{}).(*pending); ok {
+		pending.events = append(pending.events, event)
+		return nil
+	}
+
+	p.registry.dispatch([]Event{event})
+	return nil
+}
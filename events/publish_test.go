@@ -0,0 +1,91 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct {
+	ID int
+}
+
+func (testEvent) EventName() string {
+	return "test.created"
+}
+
+func TestPublish(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+		synced     []Event
+		async      int32
+	)
+
+	service.Subscribe("test.created", Sync, func(ctx context.Context, event Event) error {
+		synced = append(synced, event)
+		return nil
+	})
+	service.Subscribe("test.created", Async, func(ctx context.Context, event Event) error {
+		assert.Equal(t, testEvent{ID: 1}, event)
+		atomic.AddInt32(&async, 1)
+		return errors.New("logged only")
+	})
+	service.Subscribe("test.deleted", Sync, func(ctx context.Context, event Event) error {
+		t.Fatal("unexpected event")
+		return nil
+	})
+
+	assert.Nil(t, service.Publish(ctx, testEvent{ID: 1}))
+	service.Wait()
+
+	assert.Equal(t, []Event{testEvent{ID: 1}}, synced)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&async))
+	repository.AssertExpectations(t)
+}
+
+func TestPublish_syncError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+		err        = errors.New("sync error")
+	)
+
+	service.Subscribe("test.created", Sync, func(ctx context.Context, event Event) error {
+		return err
+	})
+	service.Subscribe("test.created", Async, func(ctx context.Context, event Event) error {
+		t.Fatal("async handler called after sync handler failed")
+		return nil
+	})
+
+	assert.Equal(t, err, service.Publish(ctx, testEvent{ID: 1}))
+	service.Wait()
+
+	repository.AssertExpectations(t)
+}
+
+func TestPublish_asyncPanic(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+	)
+
+	service.Subscribe("test.created", Async, func(ctx context.Context, event Event) error {
+		panic("async panic")
+	})
+
+	assert.NotPanics(t, func() {
+		assert.Nil(t, service.Publish(ctx, testEvent{ID: 1}))
+		service.Wait()
+	})
+
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "events")))
+)
+
+//go:generate mockery --name=Service --case=underscore --output eventstest --outpkg eventstest
+
+// Service is the central registry of domain events.
+// Domain services publish events instead of calling each other directly, and cross-cutting features subscribe to the events they care about.
+type Service interface {
+	Subscribe(name string, mode Mode, handler Handler)
+	Publish(ctx context.Context, event Event) error
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Wait()
+}
+
+// beside embeding the struct, you can also declare the function directly on this struct.
+// the advantage of embedding the struct is it allows spreading the implementation across multiple files.
+type service struct {
+	*registry
+	publish
+	transaction
+}
+
+var _ Service = (*service)(nil)
+
+// New events service.
+func New(repository rel.Repository) Service {
+	registry := &registry{
+		subscriptions: make(map[string][]subscription),
+	}
+
+	return service{
+		registry:    registry,
+		publish:     publish{registry: registry},
+		transaction: transaction{repository: repository, registry: registry},
+	}
+}
+
+type registry struct {
+	lock          sync.RWMutex
+	subscriptions map[string][]subscription
+	running       sync.WaitGroup
+}
+
+// Subscribe handler to an event name.
+// Subscriptions are expected to be registered when the application is wired, before any event is published.
+func (r *registry) Subscribe(name string, mode Mode, handler Handler) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.subscriptions[name] = append(r.subscriptions[name], subscription{mode: mode, handler: handler})
+}
+
+// Wait for every running async handler to finish, used during graceful shutdown.
+func (r *registry) Wait() {
+	r.running.Wait()
+}
+
+func (r *registry) lookup(name string, mode Mode) []Handler {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var handlers []Handler
+	for _, subscription := range r.subscriptions[name] {
+		if subscription.mode == mode {
+			handlers = append(handlers, subscription.handler)
+		}
+	}
+
+	return handlers
+}
+
+// dispatch async handlers of events in background.
+// handlers aren't bound to the publisher's context since it's usually cancelled as soon as the request finished.
+func (r *registry) dispatch(events []Event) {
+	for _, event := range events {
+		for _, handler := range r.lookup(event.EventName(), Async) {
+			r.running.Add(1)
+
+			go func(event Event, handler Handler) {
+				defer r.running.Done()
+				defer func() {
+					if rec := recover(); rec != nil {
+						logger.Error("async handler panic", zap.String("event", event.EventName()), zap.Any("panic", rec))
+					}
+				}()
+
+				if err := handler(context.Background(), event); err != nil {
+					logger.Error("async handler error", zap.String("event", event.EventName()), zap.Error(err))
+				}
+			}(event, handler)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+)
+
+type pendingKey struct{}
+
+// pending async events published inside a transaction.
+type pending struct {
+	events []Event
+}
+
+type transaction struct {
+	repository rel.Repository
+	registry   *registry
+}
+
+// Transaction runs fn inside repository transaction and dispatches async handlers of events published by fn after it's committed.
+// Nested transaction hands its events to the outer one, so nothing is dispatched until the outermost transaction is committed.
+func (t transaction) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	var (
+		parent, nested = ctx.Value(pendingKey{}).(*pending)
+		current        = &pending{}
+	)
+
+	if err := t.repository.Transaction(context.WithValue(ctx, pendingKey{}, current), fn); err != nil {
+		return err
+	}
+
+	if nested {
+		parent.events = append(parent.events, current.events...)
+		return nil
+	}
+
+	t.registry.dispatch(current.events)
+	return nil
+}
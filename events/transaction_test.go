@@ -0,0 +1,111 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+		synced     int32
+		async      int32
+	)
+
+	service.Subscribe("test.created", Sync, func(ctx context.Context, event Event) error {
+		atomic.AddInt32(&synced, 1)
+		return nil
+	})
+	service.Subscribe("test.created", Async, func(ctx context.Context, event Event) error {
+		atomic.AddInt32(&async, 1)
+		return nil
+	})
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {})
+
+	assert.Nil(t, service.Transaction(ctx, func(ctx context.Context) error {
+		assert.Nil(t, service.Publish(ctx, testEvent{ID: 1}))
+		assert.Nil(t, service.Publish(ctx, testEvent{ID: 2}))
+
+		// async handlers must wait for commit.
+		service.Wait()
+		assert.Equal(t, int32(2), atomic.LoadInt32(&synced))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&async))
+		return nil
+	}))
+
+	service.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&async))
+	repository.AssertExpectations(t)
+}
+
+func TestTransaction_rollback(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+		err        = errors.New("rollback")
+	)
+
+	service.Subscribe("test.created", Async, func(ctx context.Context, event Event) error {
+		t.Fatal("async handler called after rollback")
+		return nil
+	})
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {})
+
+	assert.Equal(t, err, service.Transaction(ctx, func(ctx context.Context) error {
+		assert.Nil(t, service.Publish(ctx, testEvent{ID: 1}))
+		return err
+	}))
+
+	service.Wait()
+	repository.AssertExpectations(t)
+}
+
+func TestTransaction_nested(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+		async      []Event
+		err        = errors.New("rollback")
+	)
+
+	service.Subscribe("test.created", Async, func(ctx context.Context, event Event) error {
+		async = append(async, event)
+		return nil
+	})
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectTransaction(func(repository *reltest.Repository) {})
+		repository.ExpectTransaction(func(repository *reltest.Repository) {})
+	})
+
+	assert.Nil(t, service.Transaction(ctx, func(ctx context.Context) error {
+		assert.Nil(t, service.Transaction(ctx, func(ctx context.Context) error {
+			return service.Publish(ctx, testEvent{ID: 1})
+		}))
+
+		// events of rolled back nested transaction are discarded.
+		assert.Equal(t, err, service.Transaction(ctx, func(ctx context.Context) error {
+			assert.Nil(t, service.Publish(ctx, testEvent{ID: 2}))
+			return err
+		}))
+
+		service.Wait()
+		assert.Empty(t, async)
+		return nil
+	}))
+
+	service.Wait()
+	assert.Equal(t, []Event{testEvent{ID: 1}}, async)
+	repository.AssertExpectations(t)
+}
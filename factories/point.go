@@ -0,0 +1,43 @@
+package factories
+
+import (
+	"context"
+
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/go-rel/rel"
+)
+
+// PointFunc overrides point fields.
+type PointFunc func(point *scores.Point)
+
+// Point builds point with default values.
+func Point(funcs ...PointFunc) scores.Point {
+	point := scores.Point{
+		Name:  "todo completed",
+		Count: 1,
+	}
+
+	for i := range funcs {
+		funcs[i](&point)
+	}
+
+	return point
+}
+
+// CreatePoint builds and inserts point.
+// Score will be created when score id is not set.
+func CreatePoint(ctx context.Context, repository rel.Repository, funcs ...PointFunc) scores.Point {
+	point := Point(funcs...)
+
+	if point.ScoreID == 0 {
+		score := CreateScore(ctx, repository, func(score *scores.Score) {
+			score.TotalPoint = point.Count
+		})
+
+		point.ScoreID = score.ID
+	}
+
+	repository.MustInsert(ctx, &point)
+
+	return point
+}
@@ -0,0 +1,46 @@
+package factories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/factories"
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoint(t *testing.T) {
+	assert.Equal(t, scores.Point{Name: "todo completed", Count: 1}, factories.Point())
+}
+
+func TestCreatePoint(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectInsert().For(&scores.Score{TotalPoint: 1})
+	repository.ExpectInsert().For(&scores.Point{Name: "todo completed", Count: 1, ScoreID: 1})
+
+	point := factories.CreatePoint(ctx, repository)
+	assert.Equal(t, 1, point.ScoreID)
+
+	repository.AssertExpectations(t)
+}
+
+func TestCreatePoint_withScore(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectInsert().For(&scores.Point{Name: "todo completed", Count: 1, ScoreID: 2})
+
+	point := factories.CreatePoint(ctx, repository, func(point *scores.Point) {
+		point.ScoreID = 2
+	})
+	assert.Equal(t, 2, point.ScoreID)
+
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,30 @@
+package factories
+
+import (
+	"context"
+
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/go-rel/rel"
+)
+
+// ScoreFunc overrides score fields.
+type ScoreFunc func(score *scores.Score)
+
+// Score builds score with default values.
+func Score(funcs ...ScoreFunc) scores.Score {
+	score := scores.Score{}
+
+	for i := range funcs {
+		funcs[i](&score)
+	}
+
+	return score
+}
+
+// CreateScore builds and inserts score.
+func CreateScore(ctx context.Context, repository rel.Repository, funcs ...ScoreFunc) scores.Score {
+	score := Score(funcs...)
+	repository.MustInsert(ctx, &score)
+
+	return score
+}
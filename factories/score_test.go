@@ -0,0 +1,34 @@
+package factories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/factories"
+	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	assert.Equal(t, scores.Score{}, factories.Score())
+	assert.Equal(t, scores.Score{TotalPoint: 10}, factories.Score(func(score *scores.Score) {
+		score.TotalPoint = 10
+	}))
+}
+
+func TestCreateScore(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectInsert().For(&scores.Score{TotalPoint: 10})
+
+	score := factories.CreateScore(ctx, repository, func(score *scores.Score) {
+		score.TotalPoint = 10
+	})
+	assert.NotEmpty(t, score.ID)
+
+	repository.AssertExpectations(t)
+}
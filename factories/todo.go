@@ -0,0 +1,32 @@
+package factories
+
+import (
+	"context"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+)
+
+// TodoFunc overrides todo fields.
+type TodoFunc func(todo *todos.Todo)
+
+// Todo builds todo with default values.
+func Todo(funcs ...TodoFunc) todos.Todo {
+	todo := todos.Todo{
+		Title: "Sleep",
+	}
+
+	for i := range funcs {
+		funcs[i](&todo)
+	}
+
+	return todo
+}
+
+// CreateTodo builds and inserts todo.
+func CreateTodo(ctx context.Context, repository rel.Repository, funcs ...TodoFunc) todos.Todo {
+	todo := Todo(funcs...)
+	repository.MustInsert(ctx, &todo)
+
+	return todo
+}
@@ -0,0 +1,35 @@
+package factories_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/factories"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodo(t *testing.T) {
+	assert.Equal(t, todos.Todo{Title: "Sleep"}, factories.Todo())
+	assert.Equal(t, todos.Todo{ID: 1, Title: "Wake", Completed: true}, factories.Todo(func(todo *todos.Todo) {
+		todo.ID = 1
+		todo.Title = "Wake"
+	}, func(todo *todos.Todo) {
+		todo.Completed = true
+	}))
+}
+
+func TestCreateTodo(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+	)
+
+	repository.ExpectInsert().For(&todos.Todo{Title: "Sleep"})
+
+	todo := factories.CreateTodo(ctx, repository)
+	assert.NotEmpty(t, todo.ID)
+
+	repository.AssertExpectations(t)
+}
@@ -7,6 +7,7 @@ require (
 	github.com/go-rel/postgres v0.8.0
 	github.com/go-rel/rel v0.39.0
 	github.com/go-rel/reltest v0.11.0
+	github.com/go-rel/sql v0.12.0
 	github.com/goware/cors v1.1.1
 	github.com/lib/pq v1.10.7
 	github.com/stretchr/testify v1.8.1
@@ -15,7 +16,6 @@ require (
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/go-rel/sql v0.12.0 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/serenize/snaker v0.0.0-20201027110005-a7ad2135616e // indirect
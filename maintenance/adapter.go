@@ -0,0 +1,121 @@
+package maintenance
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+)
+
+// Adapter refuses writes while read only mode is enabled, so a write that slips through the middleware never reaches the database.
+// Reads outside transaction are routed to the replica while read only, eg: when the primary is failing over.
+type Adapter struct {
+	rel.Adapter
+	replica rel.Adapter
+	service Service
+	tx      bool
+}
+
+// Wrap primary adapter using the maintenance service status, replica is optional.
+func Wrap(primary rel.Adapter, replica rel.Adapter, service Service) Adapter {
+	return Adapter{
+		Adapter: primary,
+		replica: replica,
+		service: service,
+	}
+}
+
+func (a Adapter) readOnly() bool {
+	return a.service.Status().ReadOnly
+}
+
+func (a Adapter) reader() rel.Adapter {
+	if a.replica != nil && !a.tx && a.readOnly() {
+		return a.replica
+	}
+
+	return a.Adapter
+}
+
+// Instrumentation set instrumenter of primary and replica.
+func (a Adapter) Instrumentation(instrumenter rel.Instrumenter) {
+	a.Adapter.Instrumentation(instrumenter)
+	if a.replica != nil {
+		a.replica.Instrumentation(instrumenter)
+	}
+}
+
+// Query performs query using replica while read only.
+func (a Adapter) Query(ctx context.Context, query rel.Query) (rel.Cursor, error) {
+	return a.reader().Query(ctx, query)
+}
+
+// Aggregate performs aggregate query using replica while read only.
+func (a Adapter) Aggregate(ctx context.Context, query rel.Query, mode string, field string) (int, error) {
+	return a.reader().Aggregate(ctx, query, mode, field)
+}
+
+// Insert performs insert query unless read only.
+func (a Adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (any, error) {
+	if a.readOnly() {
+		return nil, ErrReadOnly
+	}
+
+	return a.Adapter.Insert(ctx, query, primaryField, mutates, onConflict)
+}
+
+// InsertAll performs bulk insert query unless read only.
+func (a Adapter) InsertAll(ctx context.Context, query rel.Query, primaryField string, fields []string, bulkMutates []map[string]rel.Mutate, onConflict rel.OnConflict) ([]any, error) {
+	if a.readOnly() {
+		return nil, ErrReadOnly
+	}
+
+	return a.Adapter.InsertAll(ctx, query, primaryField, fields, bulkMutates, onConflict)
+}
+
+// Update performs update query unless read only.
+func (a Adapter) Update(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate) (int, error) {
+	if a.readOnly() {
+		return 0, ErrReadOnly
+	}
+
+	return a.Adapter.Update(ctx, query, primaryField, mutates)
+}
+
+// Delete performs delete query unless read only.
+func (a Adapter) Delete(ctx context.Context, query rel.Query) (int, error) {
+	if a.readOnly() {
+		return 0, ErrReadOnly
+	}
+
+	return a.Adapter.Delete(ctx, query)
+}
+
+// Exec performs raw statement unless read only, raw statement is always treated as write.
+func (a Adapter) Exec(ctx context.Context, stmt string, args []any) (int64, int64, error) {
+	if a.readOnly() {
+		return 0, 0, ErrReadOnly
+	}
+
+	return a.Adapter.Exec(ctx, stmt, args)
+}
+
+// Apply migration unless read only.
+func (a Adapter) Apply(ctx context.Context, migration rel.Migration) error {
+	if a.readOnly() {
+		return ErrReadOnly
+	}
+
+	return a.Adapter.Apply(ctx, migration)
+}
+
+// Begin transaction on primary, reads inside transaction are never routed to replica.
+func (a Adapter) Begin(ctx context.Context) (rel.Adapter, error) {
+	adapter, err := a.Adapter.Begin(ctx)
+	if err != nil {
+		return adapter, err
+	}
+
+	a.Adapter = adapter
+	a.tx = true
+	return a, nil
+}
@@ -0,0 +1,119 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+// adapter records the name of adapter used without touching database.
+type adapter struct {
+	rel.Adapter
+	name string
+	used *[]string
+}
+
+func (a adapter) Query(ctx context.Context, query rel.Query) (rel.Cursor, error) {
+	*a.used = append(*a.used, a.name)
+	return nil, nil
+}
+
+func (a adapter) Aggregate(ctx context.Context, query rel.Query, mode string, field string) (int, error) {
+	*a.used = append(*a.used, a.name)
+	return 1, nil
+}
+
+func (a adapter) Insert(ctx context.Context, query rel.Query, primaryField string, mutates map[string]rel.Mutate, onConflict rel.OnConflict) (any, error) {
+	*a.used = append(*a.used, a.name)
+	return 1, nil
+}
+
+func (a adapter) Exec(ctx context.Context, stmt string, args []any) (int64, int64, error) {
+	*a.used = append(*a.used, a.name)
+	return 0, 1, nil
+}
+
+func (a adapter) Begin(ctx context.Context) (rel.Adapter, error) {
+	a.name += "-tx"
+	return a, nil
+}
+
+func TestAdapter(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		used    []string
+		primary = adapter{name: "primary", used: &used}
+		replica = adapter{name: "replica", used: &used}
+		wrapped = Wrap(primary, replica, New(reltest.New(), false, false))
+	)
+
+	_, err := wrapped.Query(ctx, rel.From("todos"))
+	assert.Nil(t, err)
+
+	_, err = wrapped.Insert(ctx, rel.From("todos"), "id", nil, rel.OnConflict{})
+	assert.Nil(t, err)
+
+	_, _, err = wrapped.Exec(ctx, "SELECT 1;", nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"primary", "primary", "primary"}, used)
+}
+
+func TestAdapter_readOnly(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		used    []string
+		primary = adapter{name: "primary", used: &used}
+		replica = adapter{name: "replica", used: &used}
+		wrapped = Wrap(primary, replica, New(reltest.New(), false, true))
+	)
+
+	_, err := wrapped.Query(ctx, rel.From("todos"))
+	assert.Nil(t, err)
+
+	_, err = wrapped.Aggregate(ctx, rel.From("todos"), "count", "*")
+	assert.Nil(t, err)
+
+	_, err = wrapped.Insert(ctx, rel.From("todos"), "id", nil, rel.OnConflict{})
+	assert.Equal(t, ErrReadOnly, err)
+
+	_, err = wrapped.Update(ctx, rel.From("todos"), "id", nil)
+	assert.Equal(t, ErrReadOnly, err)
+
+	_, err = wrapped.Delete(ctx, rel.From("todos"))
+	assert.Equal(t, ErrReadOnly, err)
+
+	_, _, err = wrapped.Exec(ctx, "SELECT 1;", nil)
+	assert.Equal(t, ErrReadOnly, err)
+
+	assert.Equal(t, ErrReadOnly, wrapped.Apply(ctx, rel.Table{}))
+
+	// reads inside transaction stay in the transaction.
+	tx, err := wrapped.Begin(ctx)
+	assert.Nil(t, err)
+
+	_, err = tx.Query(ctx, rel.From("todos"))
+	assert.Nil(t, err)
+
+	_, err = tx.Insert(ctx, rel.From("todos"), "id", nil, rel.OnConflict{})
+	assert.Equal(t, ErrReadOnly, err)
+
+	assert.Equal(t, []string{"replica", "replica", "primary-tx"}, used)
+}
+
+func TestAdapter_readOnlyWithoutReplica(t *testing.T) {
+	var (
+		ctx     = context.TODO()
+		used    []string
+		primary = adapter{name: "primary", used: &used}
+		wrapped = Wrap(primary, nil, New(reltest.New(), false, true))
+	)
+
+	_, err := wrapped.Query(ctx, rel.From("todos"))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"primary"}, used)
+}
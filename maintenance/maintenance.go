@@ -0,0 +1,54 @@
+package maintenance
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// DefaultMessage returned when maintenance message is not set.
+	DefaultMessage = "Service is under maintenance, please try again later"
+	// DefaultRetryAfter in seconds used when retry after is not set.
+	DefaultRetryAfter = 300
+	// ErrReadOnly returned by Adapter when writing while read only mode is enabled.
+	ErrReadOnly = errors.New("maintenance: database is read only")
+	// ErrMaintenanceRetryAfterInvalid validation error.
+	ErrMaintenanceRetryAfterInvalid = errors.New("Retry after can't be negative")
+)
+
+// Maintenance represent maintenance state stored in maintenances table.
+// Only one record is stored, it's shared by every running instance.
+// Enabled rejects every request, while ReadOnly only rejects request that writes and keeps serving reads.
+type Maintenance struct {
+	ID         int       `json:"-"`
+	Enabled    bool      `json:"enabled"`
+	ReadOnly   bool      `json:"read_only"`
+	Message    string    `json:"message"`
+	RetryAfter int       `json:"retry_after"`
+	CreatedAt  time.Time `json:"-"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// Validate maintenance.
+func (m Maintenance) Validate() error {
+	var err error
+	switch {
+	case m.RetryAfter < 0:
+		err = ErrMaintenanceRetryAfterInvalid
+	}
+
+	return err
+}
+
+// WithDefault returns maintenance with default message and retry after applied.
+func (m Maintenance) WithDefault() Maintenance {
+	if m.Message == "" {
+		m.Message = DefaultMessage
+	}
+
+	if m.RetryAfter == 0 {
+		m.RetryAfter = DefaultRetryAfter
+	}
+
+	return m
+}
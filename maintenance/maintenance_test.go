@@ -0,0 +1,22 @@
+package maintenance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenance_Validate(t *testing.T) {
+	t.Run("retry after is negative", func(t *testing.T) {
+		assert.Equal(t, ErrMaintenanceRetryAfterInvalid, Maintenance{RetryAfter: -1}.Validate())
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		assert.Nil(t, Maintenance{Enabled: true}.Validate())
+	})
+}
+
+func TestMaintenance_WithDefault(t *testing.T) {
+	assert.Equal(t, Maintenance{Message: DefaultMessage, RetryAfter: DefaultRetryAfter}, Maintenance{}.WithDefault())
+	assert.Equal(t, Maintenance{Message: "Upgrading", RetryAfter: 60}, Maintenance{Message: "Upgrading", RetryAfter: 60}.WithDefault())
+}
@@ -0,0 +1,38 @@
+package maintenancetest
+
+import (
+	context "context"
+
+	maintenance "github.com/Fs02/go-todo-backend/maintenance"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFunc function.
+type MockFunc func(service *Service)
+
+// Mock apply mock maintenance functions.
+func Mock(service *Service, funcs ...MockFunc) {
+	for i := range funcs {
+		if funcs[i] != nil {
+			funcs[i](service)
+		}
+	}
+}
+
+// MockStatus util.
+func MockStatus(result maintenance.Maintenance) MockFunc {
+	return func(service *Service) {
+		service.On("Status").Return(result.WithDefault())
+	}
+}
+
+// MockUpdate util.
+func MockUpdate(result maintenance.Maintenance, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Update", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, out *maintenance.Maintenance) error {
+				*out = result
+				return err
+			})
+	}
+}
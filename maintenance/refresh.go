@@ -0,0 +1,26 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-rel/rel"
+)
+
+type refresh struct {
+	repository rel.Repository
+	state      *state
+}
+
+func (r refresh) Refresh(ctx context.Context) error {
+	var (
+		maintenance Maintenance
+	)
+
+	if err := r.repository.Find(ctx, &maintenance); err != nil && !errors.Is(err, rel.ErrNotFound) {
+		return err
+	}
+
+	r.state.set(maintenance)
+	return nil
+}
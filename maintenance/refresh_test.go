@@ -0,0 +1,89 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefresh(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, false, false)
+	)
+
+	assert.False(t, service.Status().Enabled)
+
+	repository.ExpectFind().Result(Maintenance{ID: 1, Enabled: true, Message: "Upgrading"})
+
+	assert.Nil(t, service.Refresh(ctx))
+	assert.Equal(t, Maintenance{ID: 1, Enabled: true, Message: "Upgrading", RetryAfter: DefaultRetryAfter}, service.Status())
+
+	repository.AssertExpectations(t)
+}
+
+func TestRefresh_notFound(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, false, false)
+	)
+
+	repository.ExpectFind().NotFound()
+
+	assert.Nil(t, service.Refresh(ctx))
+	assert.False(t, service.Status().Enabled)
+
+	repository.AssertExpectations(t)
+}
+
+func TestRefresh_forced(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, true, false)
+	)
+
+	assert.True(t, service.Status().Enabled)
+
+	repository.ExpectFind().Result(Maintenance{ID: 1, Enabled: false})
+
+	assert.Nil(t, service.Refresh(ctx))
+	assert.True(t, service.Status().Enabled)
+
+	repository.AssertExpectations(t)
+}
+
+func TestRefresh_findError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, false, false)
+	)
+
+	repository.ExpectFind().ConnectionClosed()
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Refresh(ctx))
+
+	repository.AssertExpectations(t)
+}
+
+func TestRefresh_forcedReadOnly(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, false, true)
+	)
+
+	assert.True(t, service.Status().ReadOnly)
+
+	repository.ExpectFind().Result(Maintenance{ID: 1, ReadOnly: false})
+	assert.Nil(t, service.Refresh(ctx))
+	assert.True(t, service.Status().ReadOnly)
+	assert.False(t, service.Status().Enabled)
+
+	repository.AssertExpectations(t)
+}
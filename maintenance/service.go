@@ -0,0 +1,45 @@
+package maintenance
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "maintenance")))
+)
+
+//go:generate mockery --name=Service --case=underscore --output maintenancetest --outpkg maintenancetest
+
+// Service instance for maintenance's domain.
+// Any operation done to any of object within this domain should use this service.
+type Service interface {
+	Status() Maintenance
+	Refresh(ctx context.Context) error
+	Update(ctx context.Context, maintenance *Maintenance) error
+}
+
+// beside embeding the struct, you can also declare the function directly on this struct.
+// the advantage of embedding the struct is it allows spreading the implementation across multiple files.
+type service struct {
+	*state
+	refresh
+	update
+}
+
+var _ Service = (*service)(nil)
+
+// New Maintenance service.
+// Status is only loaded from database when refreshed, forced enables maintenance and readOnly enables read only mode regardless of the stored state.
+// Repository must not be wrapped using Adapter, so maintenance can still be updated while read only.
+func New(repository rel.Repository, forced bool, readOnly bool) Service {
+	state := &state{forced: forced, readOnly: readOnly}
+
+	return service{
+		state:   state,
+		refresh: refresh{repository: repository, state: state},
+		update:  update{repository: repository, state: state},
+	}
+}
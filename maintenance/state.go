@@ -0,0 +1,36 @@
+package maintenance
+
+import (
+	"sync"
+)
+
+// state holds the last known maintenance, so checking maintenance doesn't require query on every request.
+type state struct {
+	lock        sync.RWMutex
+	maintenance Maintenance
+	forced      bool
+	readOnly    bool
+}
+
+func (s *state) Status() Maintenance {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	maintenance := s.maintenance
+	if s.forced {
+		maintenance.Enabled = true
+	}
+
+	if s.readOnly {
+		maintenance.ReadOnly = true
+	}
+
+	return maintenance.WithDefault()
+}
+
+func (s *state) set(maintenance Maintenance) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.maintenance = maintenance
+}
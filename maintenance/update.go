@@ -0,0 +1,47 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+type update struct {
+	repository rel.Repository
+	state      *state
+}
+
+func (u update) Update(ctx context.Context, maintenance *Maintenance) error {
+	if err := maintenance.Validate(); err != nil {
+		logger.Warn("validation error", zap.Error(err))
+		return err
+	}
+
+	err := u.repository.Transaction(ctx, func(ctx context.Context) error {
+		var (
+			current Maintenance
+		)
+
+		// there's only one maintenance record, always update the first one.
+		if err := u.repository.Find(ctx, &current, rel.ForUpdate()); err != nil {
+			if !errors.Is(err, rel.ErrNotFound) {
+				// unexpected error.
+				return err
+			}
+
+			return u.repository.Insert(ctx, maintenance)
+		}
+
+		maintenance.ID = current.ID
+		maintenance.CreatedAt = current.CreatedAt
+		return u.repository.Update(ctx, maintenance)
+	})
+
+	if err == nil {
+		u.state.set(*maintenance)
+	}
+
+	return err
+}
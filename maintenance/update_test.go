@@ -0,0 +1,81 @@
+package maintenance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/rel"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate(t *testing.T) {
+	var (
+		ctx         = context.TODO()
+		repository  = reltest.New()
+		service     = New(repository, false, false)
+		maintenance = Maintenance{Enabled: true, Message: "Upgrading", RetryAfter: 60}
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectFind(rel.ForUpdate()).Result(Maintenance{ID: 1})
+		repository.ExpectUpdate().ForType("maintenance.Maintenance")
+	})
+
+	assert.Nil(t, service.Update(ctx, &maintenance))
+	assert.Equal(t, 1, maintenance.ID)
+	assert.Equal(t, maintenance, service.Status())
+
+	repository.AssertExpectations(t)
+}
+
+func TestUpdate_insert(t *testing.T) {
+	var (
+		ctx         = context.TODO()
+		repository  = reltest.New()
+		service     = New(repository, false, false)
+		maintenance = Maintenance{Enabled: true}
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectFind(rel.ForUpdate()).NotFound()
+		repository.ExpectInsert().For(&maintenance)
+	})
+
+	assert.Nil(t, service.Update(ctx, &maintenance))
+	assert.NotEmpty(t, maintenance.ID)
+	assert.True(t, service.Status().Enabled)
+
+	repository.AssertExpectations(t)
+}
+
+func TestUpdate_findError(t *testing.T) {
+	var (
+		ctx         = context.TODO()
+		repository  = reltest.New()
+		service     = New(repository, false, false)
+		maintenance = Maintenance{Enabled: true}
+	)
+
+	repository.ExpectTransaction(func(repository *reltest.Repository) {
+		repository.ExpectFind(rel.ForUpdate()).ConnectionClosed()
+	})
+
+	assert.Equal(t, reltest.ErrConnectionClosed, service.Update(ctx, &maintenance))
+	assert.False(t, service.Status().Enabled)
+
+	repository.AssertExpectations(t)
+}
+
+func TestUpdate_validateError(t *testing.T) {
+	var (
+		ctx         = context.TODO()
+		repository  = reltest.New()
+		service     = New(repository, false, false)
+		maintenance = Maintenance{RetryAfter: -1}
+	)
+
+	assert.Equal(t, ErrMaintenanceRetryAfterInvalid, service.Update(ctx, &maintenance))
+
+	repository.AssertExpectations(t)
+}
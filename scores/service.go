@@ -22,7 +22,7 @@ type service struct {
 
 var _ Service = (*service)(nil)
 
-// New Scores service.
+// New Scores service, use Subscribe to earn points from todo events.
 func New(repository rel.Repository) Service {
 	return service{
 		earn: earn{repository: repository},
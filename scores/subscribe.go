@@ -0,0 +1,20 @@
+package scores
+
+import (
+	"context"
+
+	"github.com/Fs02/go-todo-backend/events"
+	"github.com/Fs02/go-todo-backend/todos"
+)
+
+// Subscribe service to todo events that earn points, it must be called once per registry, otherwise points are earned multiple times.
+// points are earned synchronously, so it's rolled back together with the todo when earning fails.
+func Subscribe(registry events.Service, service Service) {
+	registry.Subscribe(todos.EventCompleted, events.Sync, func(ctx context.Context, _ events.Event) error {
+		return service.Earn(ctx, "todo completed", 1)
+	})
+
+	registry.Subscribe(todos.EventUncompleted, events.Sync, func(ctx context.Context, _ events.Event) error {
+		return service.Earn(ctx, "todo uncompleted", -2)
+	})
+}
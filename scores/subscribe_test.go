@@ -0,0 +1,55 @@
+package scores
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/events"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+	tests := []struct {
+		name  string
+		event events.Event
+		point Point
+		total int
+	}{
+		{
+			name:  "completed",
+			event: todos.Completed{Todo: todos.Todo{ID: 1, Completed: true}},
+			point: Point{Name: "todo completed", Count: 1, ScoreID: 1},
+			total: 11,
+		},
+		{
+			name:  "uncompleted",
+			event: todos.Uncompleted{Todo: todos.Todo{ID: 1}},
+			point: Point{Name: "todo uncompleted", Count: -2, ScoreID: 1},
+			total: 8,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				registry   = events.New(repository)
+			)
+
+			Subscribe(registry, New(repository))
+
+			repository.ExpectTransaction(func(repository *reltest.Repository) {
+				repository.ExpectFind(rel.ForUpdate()).Result(Score{ID: 1, TotalPoint: 10})
+				repository.ExpectUpdate().For(&Score{ID: 1, TotalPoint: test.total})
+				repository.ExpectInsert().For(&test.point)
+			})
+
+			assert.Nil(t, registry.Publish(ctx, test.event))
+			repository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package search
+
+import (
+	"context"
+)
+
+// Engine of external search such as Meilisearch, documents are stored per index and encoded as json.
+type Engine interface {
+	// Name of the engine, returned as the source of search result.
+	Name() string
+	Configure(ctx context.Context, index string, settings Settings) error
+	Index(ctx context.Context, index string, documents interface{}) error
+	Remove(ctx context.Context, index string, ids []uint) error
+	Clear(ctx context.Context, index string) error
+	// Search index, matching documents are decoded into hits.
+	Search(ctx context.Context, index string, request Request, hits interface{}) (Response, error)
+}
+
+// Settings of an index.
+type Settings struct {
+	// Searchable fields, ordered by its importance in ranking.
+	Searchable []string
+	// Filterable fields, facets can only be requested for filterable fields.
+	Filterable []string
+}
+
+// Request to search engine.
+type Request struct {
+	Keyword string
+	// Filter documents by field equal to value.
+	Filter map[string]interface{}
+	// Facets returns number of matching documents for each value of the fields.
+	Facets []string
+	Limit  int
+	Offset int
+}
+
+// Response of search engine.
+type Response struct {
+	Total  int
+	Facets map[string]map[string]int
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"context"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+)
+
+// index keeps search engine in sync with todos, every operation is a no op when search engine is disabled.
+type index struct {
+	repository rel.Repository
+	engine     Engine
+}
+
+// Index adds or replaces todos in search engine.
+func (i index) Index(ctx context.Context, todos ...todos.Todo) error {
+	if i.engine == nil || len(todos) == 0 {
+		return nil
+	}
+
+	return i.engine.Index(ctx, TodosIndex, todos)
+}
+
+// Remove todos from search engine.
+func (i index) Remove(ctx context.Context, ids ...uint) error {
+	if i.engine == nil || len(ids) == 0 {
+		return nil
+	}
+
+	return i.engine.Remove(ctx, TodosIndex, ids)
+}
+
+// Clear every todos from search engine.
+func (i index) Clear(ctx context.Context) error {
+	if i.engine == nil {
+		return nil
+	}
+
+	return i.engine.Clear(ctx, TodosIndex)
+}
+
+// Configure todos index using TodosSettings, filtering by completed fails until the settings are applied.
+func (i index) Configure(ctx context.Context) error {
+	if i.engine == nil {
+		return nil
+	}
+
+	return i.engine.Configure(ctx, TodosIndex, TodosSettings)
+}
+
+// Sync todos by their current row in postgres, todos that are indexed and todos that no longer exist are removed.
+// Unlike Index, the result doesn't depend on the order changes are synced, since it never indexes an outdated todo.
+func (i index) Sync(ctx context.Context, ids ...uint) error {
+	if i.engine == nil || len(ids) == 0 {
+		return nil
+	}
+
+	var (
+		found   []todos.Todo
+		exists  = make(map[uint]bool, len(ids))
+		missing []uint
+	)
+
+	if err := i.repository.FindAll(ctx, &found, where.InUint("id", ids)); err != nil {
+		return err
+	}
+
+	for _, todo := range found {
+		exists[todo.ID] = true
+	}
+
+	for _, id := range ids {
+		if !exists[id] {
+			missing = append(missing, id)
+		}
+	}
+
+	if err := i.Index(ctx, found...); err != nil {
+		return err
+	}
+
+	return i.Remove(ctx, missing...)
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex(t *testing.T) {
+	var (
+		ctx   = context.TODO()
+		fake  = &engine{}
+		index = index{engine: fake}
+		todo  = todos.Todo{ID: 1, Title: "Sleep"}
+	)
+
+	assert.Nil(t, index.Index(ctx, todo))
+	assert.Nil(t, index.Index(ctx))
+	assert.Nil(t, index.Remove(ctx, 1))
+	assert.Nil(t, index.Remove(ctx))
+	assert.Nil(t, index.Clear(ctx))
+	assert.Nil(t, index.Configure(ctx))
+
+	assert.Equal(t, []todos.Todo{todo}, fake.hits)
+	assert.Equal(t, []string{"todos:index", "todos:remove", "todos:clear", "todos:configure"}, fake.calls)
+}
+
+func TestIndex_disabled(t *testing.T) {
+	var (
+		ctx   = context.TODO()
+		index = index{}
+	)
+
+	assert.Nil(t, index.Index(ctx, todos.Todo{ID: 1}))
+	assert.Nil(t, index.Remove(ctx, 1))
+	assert.Nil(t, index.Clear(ctx))
+	assert.Nil(t, index.Sync(ctx, 1))
+	assert.Nil(t, index.Configure(ctx))
+}
+
+func TestIndex_Sync(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		fake       = &engine{}
+		repository = reltest.New()
+		index      = index{repository: repository, engine: fake}
+		todo       = todos.Todo{ID: 1, Title: "Sleep"}
+	)
+
+	repository.ExpectFindAll(where.InUint("id", []uint{1, 2})).Result([]todos.Todo{todo})
+
+	assert.Nil(t, index.Sync(ctx, 1, 2))
+	assert.Nil(t, index.Sync(ctx))
+
+	assert.Equal(t, []todos.Todo{todo}, fake.hits)
+	assert.Equal(t, []string{"todos:index", "todos:remove"}, fake.calls)
+	repository.AssertExpectations(t)
+}
+
+func TestIndex_Sync_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		fake       = &engine{}
+		repository = reltest.New()
+		index      = index{repository: repository, engine: fake}
+	)
+
+	repository.ExpectFindAll(where.InUint("id", []uint{1})).ConnectionClosed()
+
+	assert.Equal(t, reltest.ErrConnectionClosed, index.Sync(ctx, 1))
+	assert.Empty(t, fake.calls)
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,171 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// Timeout of request to search engine when its client is not set, kept short so search falls back to postgres quickly.
+	Timeout = 2 * time.Second
+)
+
+// Meilisearch engine, documents are indexed using "id" as primary key.
+// Typo tolerance and relevancy ranking use meilisearch defaults.
+type Meilisearch struct {
+	Address string
+	// Key is sent as bearer token, it can be empty when the instance doesn't have a master key.
+	Key    string
+	Client *http.Client
+}
+
+// Name of the engine.
+func (m Meilisearch) Name() string {
+	return "meilisearch"
+}
+
+// Configure index settings.
+func (m Meilisearch) Configure(ctx context.Context, index string, settings Settings) error {
+	return m.send(ctx, "PATCH", "/indexes/"+url.PathEscape(index)+"/settings", map[string]interface{}{
+		"searchableAttributes": settings.Searchable,
+		"filterableAttributes": settings.Filterable,
+		"typoTolerance":        map[string]interface{}{"enabled": true},
+	}, nil)
+}
+
+// Index adds or replaces documents.
+func (m Meilisearch) Index(ctx context.Context, index string, documents interface{}) error {
+	return m.send(ctx, "POST", "/indexes/"+url.PathEscape(index)+"/documents?primaryKey=id", documents, nil)
+}
+
+// Remove documents by id.
+func (m Meilisearch) Remove(ctx context.Context, index string, ids []uint) error {
+	return m.send(ctx, "POST", "/indexes/"+url.PathEscape(index)+"/documents/delete-batch", ids, nil)
+}
+
+// Clear every documents.
+func (m Meilisearch) Clear(ctx context.Context, index string) error {
+	return m.send(ctx, "DELETE", "/indexes/"+url.PathEscape(index)+"/documents", nil, nil)
+}
+
+// Search documents.
+func (m Meilisearch) Search(ctx context.Context, index string, request Request, hits interface{}) (Response, error) {
+	var (
+		body = map[string]interface{}{
+			"q":      request.Keyword,
+			"offset": request.Offset,
+		}
+		result struct {
+			Hits               json.RawMessage           `json:"hits"`
+			EstimatedTotalHits int                       `json:"estimatedTotalHits"`
+			FacetDistribution  map[string]map[string]int `json:"facetDistribution"`
+		}
+	)
+
+	if request.Limit > 0 {
+		body["limit"] = request.Limit
+	}
+
+	if len(request.Filter) > 0 {
+		body["filter"] = filter(request.Filter)
+	}
+
+	if len(request.Facets) > 0 {
+		body["facets"] = request.Facets
+	}
+
+	if err := m.send(ctx, "POST", "/indexes/"+url.PathEscape(index)+"/search", body, &result); err != nil {
+		return Response{}, err
+	}
+
+	if err := json.Unmarshal(result.Hits, hits); err != nil {
+		return Response{}, err
+	}
+
+	return Response{
+		Total:  result.EstimatedTotalHits,
+		Facets: result.FacetDistribution,
+	}, nil
+}
+
+func (m Meilisearch) send(ctx context.Context, method string, path string, body interface{}, result interface{}) error {
+	var (
+		client  = m.Client
+		payload []byte
+		err     error
+	)
+
+	if client == nil {
+		client = &http.Client{Timeout: Timeout}
+	}
+
+	if body != nil {
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(m.Address, "/")+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if m.Key != "" {
+		req.Header.Set("Authorization", "Bearer "+m.Key)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var failure struct {
+			Message string `json:"message"`
+		}
+
+		json.NewDecoder(resp.Body).Decode(&failure)
+		return fmt.Errorf("search: meilisearch responded with %s: %s", resp.Status, failure.Message)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// filter expression, eg: completed = true AND title = "Sleep".
+func filter(values map[string]interface{}) string {
+	var (
+		fields      = make([]string, 0, len(values))
+		expressions = make([]string, 0, len(values))
+	)
+
+	for field := range values {
+		fields = append(fields, field)
+	}
+
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		value := values[field]
+		if str, ok := value.(string); ok {
+			value = strconv.Quote(str)
+		}
+
+		expressions = append(expressions, fmt.Sprintf("%s = %v", field, value))
+	}
+
+	return strings.Join(expressions, " AND ")
+}
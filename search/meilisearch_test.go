@@ -0,0 +1,84 @@
+package search
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeilisearch(t *testing.T) {
+	type request struct {
+		method string
+		path   string
+		body   string
+	}
+
+	var (
+		ctx      = context.TODO()
+		requests []request
+		server   = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, request{method: r.Method, path: r.URL.RequestURI(), body: string(body)})
+
+			assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+			if r.URL.Path == "/indexes/todos/search" {
+				w.Write([]byte(`{"hits":[{"id":1,"title":"Sleep","completed":true}],"estimatedTotalHits":1,"facetDistribution":{"completed":{"true":1}}}`))
+				return
+			}
+
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"taskUid":1}`))
+		}))
+		engine = Meilisearch{Address: server.URL + "/", Key: "key"}
+		hits   []todos.Todo
+	)
+	defer server.Close()
+
+	assert.Equal(t, "meilisearch", engine.Name())
+	assert.Nil(t, engine.Configure(ctx, "todos", TodosSettings))
+	assert.Nil(t, engine.Index(ctx, "todos", []map[string]interface{}{{"id": 1}}))
+	assert.Nil(t, engine.Remove(ctx, "todos", []uint{1, 2}))
+	assert.Nil(t, engine.Clear(ctx, "todos"))
+
+	response, err := engine.Search(ctx, "todos", Request{
+		Keyword: "slep",
+		Filter:  map[string]interface{}{"completed": true, "title": "Sleep"},
+		Facets:  []string{"completed"},
+		Limit:   10,
+	}, &hits)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []todos.Todo{{ID: 1, Title: "Sleep", Completed: true}}, hits)
+	assert.Equal(t, Response{Total: 1, Facets: map[string]map[string]int{"completed": {"true": 1}}}, response)
+	assert.Equal(t, []request{
+		{method: "PATCH", path: "/indexes/todos/settings", body: `{"filterableAttributes":["completed"],"searchableAttributes":["title"],"typoTolerance":{"enabled":true}}`},
+		{method: "POST", path: "/indexes/todos/documents?primaryKey=id", body: `[{"id":1}]`},
+		{method: "POST", path: "/indexes/todos/documents/delete-batch", body: `[1,2]`},
+		{method: "DELETE", path: "/indexes/todos/documents"},
+		{method: "POST", path: "/indexes/todos/search", body: `{"facets":["completed"],"filter":"completed = true AND title = \"Sleep\"","limit":10,"offset":0,"q":"slep"}`},
+	}, requests)
+}
+
+func TestMeilisearch_error(t *testing.T) {
+	var (
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Index ` + "`todos`" + ` not found.","code":"index_not_found"}`))
+		}))
+		engine = Meilisearch{Address: server.URL}
+		hits   []todos.Todo
+	)
+	defer server.Close()
+
+	_, err := engine.Search(context.TODO(), "todos", Request{}, &hits)
+	assert.EqualError(t, err, "search: meilisearch responded with 404 Not Found: Index `todos` not found.")
+
+	server.Close()
+	assert.NotNil(t, engine.Clear(context.TODO(), "todos"))
+}
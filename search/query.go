@@ -0,0 +1,138 @@
+package search
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"go.uber.org/zap"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+type query struct {
+	repository rel.Repository
+	engine     Engine
+}
+
+// Search todos using search engine, it falls back to postgres when search engine is disabled or unavailable.
+func (q query) Search(ctx context.Context, result *Result, query Query) error {
+	if err := query.Validate(); err != nil {
+		logger.Warn("validation error", zap.Error(err))
+		return err
+	}
+
+	if q.engine != nil {
+		err := q.searchEngine(ctx, result, query)
+		if err == nil {
+			return nil
+		}
+
+		logger.Warn("search engine unavailable, falling back to postgres", zap.String("engine", q.engine.Name()), zap.Error(err))
+	}
+
+	q.searchPostgres(ctx, result, query)
+	return nil
+}
+
+func (q query) searchEngine(ctx context.Context, result *Result, query Query) error {
+	var (
+		hits    []todos.Todo
+		request = Request{
+			Keyword: query.Keyword,
+			Facets:  Facets,
+			Limit:   query.Limit,
+			Offset:  query.Offset,
+		}
+	)
+
+	if query.Completed != nil {
+		request.Filter = map[string]interface{}{"completed": *query.Completed}
+	}
+
+	response, err := q.engine.Search(ctx, TodosIndex, request, &hits)
+	if err != nil {
+		return err
+	}
+
+	*result = Result{
+		Todos:  hits,
+		Total:  response.Total,
+		Facets: response.Facets,
+		Source: q.engine.Name(),
+	}
+
+	return nil
+}
+
+// searchPostgres matches keyword case insensitively using ILIKE, so it's not typo tolerant and todos are sorted by order instead of relevance.
+func (q query) searchPostgres(ctx context.Context, result *Result, query Query) {
+	var (
+		hits    []todos.Todo
+		filters []rel.FilterQuery
+	)
+
+	if query.Keyword != "" {
+		filters = append(filters, where.Fragment("title ILIKE ?", "%"+escapeLike(query.Keyword)+"%"))
+	}
+
+	if query.Completed != nil {
+		filters = append(filters, where.Eq("completed", *query.Completed))
+	}
+
+	var (
+		matches = rel.Where(filters...)
+		find    = matches.SortAsc("order").SortAsc("id")
+	)
+
+	if query.Limit > 0 {
+		find = find.Limit(query.Limit)
+	}
+
+	if query.Offset > 0 {
+		find = find.Offset(query.Offset)
+	}
+
+	q.repository.MustFindAll(ctx, &hits, find)
+
+	var (
+		total     = q.repository.MustCount(ctx, "todos", matches)
+		completed = total
+	)
+
+	switch {
+	case query.Completed == nil:
+		completed = q.repository.MustCount(ctx, "todos", matches.Where(where.Eq("completed", true)))
+	case !*query.Completed:
+		completed = 0
+	}
+
+	*result = Result{
+		Todos: hits,
+		Total: total,
+		Facets: map[string]map[string]int{
+			"completed": facet(map[bool]int{true: completed, false: total - completed}),
+		},
+		Source: Postgres,
+	}
+}
+
+// escapeLike escapes wildcards in keyword, so it's matched literally (postgres uses backslash as the default escape character).
+func escapeLike(keyword string) string {
+	return likeEscaper.Replace(keyword)
+}
+
+// facet of boolean field without zero count, the same as search engine.
+func facet(count map[bool]int) map[string]int {
+	result := make(map[string]int)
+	for value, n := range count {
+		if n > 0 {
+			result[strconv.FormatBool(value)] = n
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,196 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+// engine fakes search engine, every call is recorded using the index name as prefix.
+type engine struct {
+	calls    []string
+	hits     []todos.Todo
+	request  Request
+	response Response
+	err      error
+}
+
+func (e *engine) Name() string {
+	return "fake"
+}
+
+func (e *engine) Configure(ctx context.Context, index string, settings Settings) error {
+	e.calls = append(e.calls, index+":configure")
+	return e.err
+}
+
+func (e *engine) Index(ctx context.Context, index string, documents interface{}) error {
+	e.calls = append(e.calls, index+":index")
+	for _, todo := range documents.([]todos.Todo) {
+		e.hits = append(e.hits, todo)
+	}
+
+	return e.err
+}
+
+func (e *engine) Remove(ctx context.Context, index string, ids []uint) error {
+	e.calls = append(e.calls, index+":remove")
+	return e.err
+}
+
+func (e *engine) Clear(ctx context.Context, index string) error {
+	e.calls = append(e.calls, index+":clear")
+	return e.err
+}
+
+func (e *engine) Search(ctx context.Context, index string, request Request, hits interface{}) (Response, error) {
+	e.calls = append(e.calls, index+":search")
+	e.request = request
+	*hits.(*[]todos.Todo) = e.hits
+	return e.response, e.err
+}
+
+func TestSearch(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		completed  = true
+		fake       = &engine{
+			hits:     []todos.Todo{{ID: 1, Title: "Sleep", Completed: true}},
+			response: Response{Total: 1, Facets: map[string]map[string]int{"completed": {"true": 1}}},
+		}
+		search = query{repository: repository, engine: fake}
+		result Result
+	)
+
+	assert.Nil(t, search.Search(ctx, &result, Query{Keyword: "slep", Completed: &completed, Limit: 10}))
+	assert.Equal(t, Result{
+		Todos:  fake.hits,
+		Total:  1,
+		Facets: fake.response.Facets,
+		Source: "fake",
+	}, result)
+	assert.Equal(t, Request{
+		Keyword: "slep",
+		Filter:  map[string]interface{}{"completed": true},
+		Facets:  Facets,
+		Limit:   10,
+	}, fake.request)
+
+	repository.AssertExpectations(t)
+}
+
+func TestSearch_fallback(t *testing.T) {
+	var (
+		completed   = true
+		uncompleted = false
+		todo        = todos.Todo{ID: 1, Title: "Sleep"}
+	)
+
+	tests := []struct {
+		name   string
+		engine Engine
+		query  Query
+		mock   func(repository *reltest.Repository)
+		result Result
+	}{
+		{
+			name:  "disabled",
+			query: Query{Keyword: "Sleep", Limit: 10, Offset: 10},
+			mock: func(repository *reltest.Repository) {
+				matches := rel.Where(where.Fragment("title ILIKE ?", "%Sleep%"))
+				repository.ExpectFindAll(matches.SortAsc("order").SortAsc("id").Limit(10).Offset(10)).Result([]todos.Todo{todo})
+				repository.ExpectCount("todos", matches).Result(15)
+				repository.ExpectCount("todos", matches.Where(where.Eq("completed", true))).Result(5)
+			},
+			result: Result{
+				Todos:  []todos.Todo{todo},
+				Total:  15,
+				Facets: map[string]map[string]int{"completed": {"true": 5, "false": 10}},
+				Source: Postgres,
+			},
+		},
+		{
+			name:  "wildcards",
+			query: Query{Keyword: `50%_off\`},
+			mock: func(repository *reltest.Repository) {
+				matches := rel.Where(where.Fragment("title ILIKE ?", `%50\%\_off\\%`))
+				repository.ExpectFindAll(matches.SortAsc("order").SortAsc("id")).Result([]todos.Todo{})
+				repository.ExpectCount("todos", matches).Result(0)
+				repository.ExpectCount("todos", matches.Where(where.Eq("completed", true))).Result(0)
+			},
+			result: Result{
+				Todos:  []todos.Todo{},
+				Facets: map[string]map[string]int{"completed": {}},
+				Source: Postgres,
+			},
+		},
+		{
+			name:   "unavailable",
+			engine: &engine{err: errors.New("connection refused")},
+			query:  Query{Completed: &completed},
+			mock: func(repository *reltest.Repository) {
+				matches := rel.Where(where.Eq("completed", true))
+				repository.ExpectFindAll(matches.SortAsc("order").SortAsc("id")).Result([]todos.Todo{})
+				repository.ExpectCount("todos", matches).Result(3)
+			},
+			result: Result{
+				Todos:  []todos.Todo{},
+				Total:  3,
+				Facets: map[string]map[string]int{"completed": {"true": 3}},
+				Source: Postgres,
+			},
+		},
+		{
+			name:  "uncompleted",
+			query: Query{Completed: &uncompleted},
+			mock: func(repository *reltest.Repository) {
+				matches := rel.Where(where.Eq("completed", false))
+				repository.ExpectFindAll(matches.SortAsc("order").SortAsc("id")).Result([]todos.Todo{todo})
+				repository.ExpectCount("todos", matches).Result(1)
+			},
+			result: Result{
+				Todos:  []todos.Todo{todo},
+				Total:  1,
+				Facets: map[string]map[string]int{"completed": {"false": 1}},
+				Source: Postgres,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				search     = query{repository: repository, engine: test.engine}
+				result     Result
+			)
+
+			test.mock(repository)
+
+			assert.Nil(t, search.Search(ctx, &result, test.query))
+			assert.Equal(t, test.result, result)
+			repository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSearch_validateError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, nil)
+		result     Result
+	)
+
+	assert.Equal(t, ErrKeywordTooLong, service.Search(ctx, &result, Query{Keyword: strings.Repeat("z", MaxKeywordLength+1)}))
+	repository.AssertExpectations(t)
+}
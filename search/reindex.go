@@ -0,0 +1,61 @@
+package search
+
+import (
+	"context"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"go.uber.org/zap"
+)
+
+type reindex struct {
+	repository rel.Repository
+	engine     Engine
+}
+
+// Reindex rebuilds todos index from postgres, todos are loaded by id in batches so it doesn't hold a long running transaction.
+// Index is cleared first to drop todos deleted while the index was out of sync, so search may return partial result until it's done.
+func (r reindex) Reindex(ctx context.Context, batchSize int) error {
+	if r.engine == nil {
+		return ErrDisabled
+	}
+
+	if err := r.engine.Configure(ctx, TodosIndex, TodosSettings); err != nil {
+		return err
+	}
+
+	if err := r.engine.Clear(ctx, TodosIndex); err != nil {
+		return err
+	}
+
+	var (
+		lastID uint
+		count  int
+	)
+
+	for {
+		var batch []todos.Todo
+		if err := r.repository.FindAll(ctx, &batch, where.Gt("id", lastID), rel.SortAsc("id"), rel.Limit(batchSize)); err != nil {
+			return err
+		}
+
+		if len(batch) == 0 {
+			break
+		}
+
+		if err := r.engine.Index(ctx, TodosIndex, batch); err != nil {
+			return err
+		}
+
+		count += len(batch)
+		lastID = batch[len(batch)-1].ID
+
+		if len(batch) < batchSize {
+			break
+		}
+	}
+
+	logger.Info("reindexed", zap.String("index", TodosIndex), zap.Int("count", count))
+	return nil
+}
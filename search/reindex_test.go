@@ -0,0 +1,55 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReindex(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		fake       = &engine{}
+		reindex    = reindex{repository: repository, engine: fake}
+		first      = []todos.Todo{{ID: 1, Title: "Sleep"}, {ID: 3, Title: "Wake up"}}
+		second     = []todos.Todo{{ID: 4, Title: "Eat"}}
+	)
+
+	repository.ExpectFindAll(where.Gt("id", uint(0)), rel.SortAsc("id"), rel.Limit(2)).Result(first)
+	repository.ExpectFindAll(where.Gt("id", uint(3)), rel.SortAsc("id"), rel.Limit(2)).Result(second)
+
+	assert.Nil(t, reindex.Reindex(ctx, 2))
+	assert.Equal(t, append(first, second...), fake.hits)
+	assert.Equal(t, []string{"todos:configure", "todos:clear", "todos:index", "todos:index"}, fake.calls)
+	repository.AssertExpectations(t)
+}
+
+func TestReindex_error(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		err        = errors.New("connection refused")
+		reindex    = reindex{repository: repository, engine: &engine{err: err}}
+	)
+
+	assert.Equal(t, err, reindex.Reindex(ctx, 2))
+	repository.AssertExpectations(t)
+}
+
+func TestReindex_disabled(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		reindex    = reindex{repository: repository}
+	)
+
+	assert.Equal(t, ErrDisabled, reindex.Reindex(ctx, 2))
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,64 @@
+package search
+
+import (
+	"errors"
+	"unicode/utf8"
+
+	"github.com/Fs02/go-todo-backend/todos"
+)
+
+const (
+	// TodosIndex is the index of todos in search engine.
+	TodosIndex = "todos"
+	// Postgres is the source of search result when search engine is disabled or unavailable.
+	Postgres = "postgres"
+	// MaxKeywordLength in characters.
+	MaxKeywordLength = 256
+)
+
+var (
+	// TodosSettings of todos index.
+	TodosSettings = Settings{
+		Searchable: []string{"title"},
+		Filterable: []string{"completed"},
+	}
+	// Facets returned in search result.
+	Facets = []string{"completed"}
+	// DefaultBatchSize of todos loaded per batch when rebuilding the index.
+	DefaultBatchSize = 1000
+	// ErrKeywordTooLong validation error.
+	ErrKeywordTooLong = errors.New("Keyword is too long")
+	// ErrDisabled returned when reindexing without search engine.
+	ErrDisabled = errors.New("search: engine is disabled")
+)
+
+// Query of search.
+type Query struct {
+	Keyword   string
+	Completed *bool
+	// Limit of returned todos, search engine default is used when zero.
+	Limit  int
+	Offset int
+}
+
+// Validate query.
+func (q Query) Validate() error {
+	var err error
+	switch {
+	case utf8.RuneCountInString(q.Keyword) > MaxKeywordLength:
+		err = ErrKeywordTooLong
+	}
+
+	return err
+}
+
+// Result of search.
+type Result struct {
+	Todos []todos.Todo `json:"todos"`
+	// Total matching todos, it's an estimation when searched using search engine.
+	Total int `json:"total"`
+	// Facets contains number of matching todos for each value of the field, eg: {"completed": {"true": 1, "false": 2}}.
+	Facets map[string]map[string]int `json:"facets"`
+	// Source of result, either the name of search engine or "postgres".
+	Source string `json:"source"`
+}
@@ -0,0 +1,31 @@
+package searchtest
+
+import (
+	context "context"
+
+	search "github.com/Fs02/go-todo-backend/search"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFunc function.
+type MockFunc func(service *Service)
+
+// Mock apply mock search functions.
+func Mock(service *Service, funcs ...MockFunc) {
+	for i := range funcs {
+		if funcs[i] != nil {
+			funcs[i](service)
+		}
+	}
+}
+
+// MockSearch util.
+func MockSearch(result search.Result, query search.Query, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Search", mock.Anything, mock.Anything, query).
+			Return(func(ctx context.Context, out *search.Result, query search.Query) error {
+				*out = result
+				return err
+			})
+	}
+}
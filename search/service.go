@@ -0,0 +1,47 @@
+package search
+
+import (
+	"context"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "search")))
+)
+
+//go:generate mockery --name=Service --case=underscore --output searchtest --outpkg searchtest
+
+// Service instance for search's domain.
+// Any operation done to search index should use this service.
+type Service interface {
+	Search(ctx context.Context, result *Result, query Query) error
+	Index(ctx context.Context, todos ...todos.Todo) error
+	Remove(ctx context.Context, ids ...uint) error
+	Sync(ctx context.Context, ids ...uint) error
+	Clear(ctx context.Context) error
+	Configure(ctx context.Context) error
+	Reindex(ctx context.Context, batchSize int) error
+}
+
+// beside embeding the struct, you can also declare the function directly on this struct.
+// the advantage of embedding the struct is it allows spreading the implementation across multiple files.
+type service struct {
+	query
+	index
+	reindex
+}
+
+var _ Service = (*service)(nil)
+
+// New Search service, search is served by postgres when engine is nil.
+// Use Subscribe to keep the index in sync with todo events.
+func New(repository rel.Repository, engine Engine) Service {
+	return service{
+		query:   query{repository: repository, engine: engine},
+		index:   index{repository: repository, engine: engine},
+		reindex: reindex{repository: repository, engine: engine},
+	}
+}
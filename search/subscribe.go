@@ -0,0 +1,45 @@
+package search
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Fs02/go-todo-backend/events"
+	"github.com/Fs02/go-todo-backend/todos"
+)
+
+// Subscribe service to todo events to keep todos index in sync, it must be called once per registry, otherwise every change is indexed multiple times.
+// index is updated asynchronously after the change is committed, failure is only logged and fixed by the next change or reindex.
+//
+// Async handlers aren't dispatched in the order the changes are committed, so handlers never index the todo carried by the event.
+// Instead, handlers of the index run one at a time and sync the todo from its current row in postgres,
+// the last handler of a todo always sees every committed change, so the todo ends up indexed the same as postgres regardless of the order.
+func Subscribe(registry events.Service, service Service) {
+	var (
+		lock sync.Mutex
+	)
+
+	handle := func(ctx context.Context, event events.Event) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		switch event := event.(type) {
+		case todos.Created:
+			return service.Sync(ctx, event.Todo.ID)
+		case todos.Updated:
+			return service.Sync(ctx, event.Todo.ID)
+		case todos.Deleted:
+			return service.Sync(ctx, event.Todo.ID)
+		case todos.Cleared:
+			// todo created while clearing might be removed from the index, it's fixed by its next change or reindex.
+			return service.Clear(ctx)
+		}
+
+		return nil
+	}
+
+	registry.Subscribe(todos.EventCreated, events.Async, handle)
+	registry.Subscribe(todos.EventUpdated, events.Async, handle)
+	registry.Subscribe(todos.EventDeleted, events.Async, handle)
+	registry.Subscribe(todos.EventCleared, events.Async, handle)
+}
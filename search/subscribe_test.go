@@ -0,0 +1,89 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/events"
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel/where"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+	var (
+		todo = todos.Todo{ID: 1, Title: "Sleep"}
+	)
+
+	tests := []struct {
+		name     string
+		event    events.Event
+		mockRepo func(repository *reltest.Repository)
+		calls    []string
+	}{
+		{
+			name:  "created",
+			event: todos.Created{Todo: todo},
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectFindAll(where.InUint("id", []uint{1})).Result([]todos.Todo{todo})
+			},
+			calls: []string{"todos:index"},
+		},
+		{
+			name:  "created then deleted",
+			event: todos.Created{Todo: todo},
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectFindAll(where.InUint("id", []uint{1})).Result([]todos.Todo{})
+			},
+			calls: []string{"todos:remove"},
+		},
+		{
+			name:  "updated",
+			event: todos.Updated{Todo: todo},
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectFindAll(where.InUint("id", []uint{1})).Result([]todos.Todo{todo})
+			},
+			calls: []string{"todos:index"},
+		},
+		{
+			name:  "completed",
+			event: todos.Completed{Todo: todo},
+		},
+		{
+			name:  "deleted",
+			event: todos.Deleted{Todo: todo},
+			mockRepo: func(repository *reltest.Repository) {
+				repository.ExpectFindAll(where.InUint("id", []uint{1})).Result([]todos.Todo{})
+			},
+			calls: []string{"todos:remove"},
+		},
+		{
+			name:  "cleared",
+			event: todos.Cleared{},
+			calls: []string{"todos:clear"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				ctx        = context.TODO()
+				repository = reltest.New()
+				registry   = events.New(repository)
+				fake       = &engine{}
+			)
+
+			Subscribe(registry, New(repository, fake))
+			if test.mockRepo != nil {
+				test.mockRepo(repository)
+			}
+
+			assert.Nil(t, registry.Publish(ctx, test.event))
+			registry.Wait()
+
+			assert.Equal(t, test.calls, fake.calls)
+			repository.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// AWS provider reads secret from AWS Secrets Manager, SecretID must store a json object and each secret is a key of the object.
+// Request is signed using Signature Version 4 with static credentials, eg: taken from the task role environment.
+type AWS struct {
+	Region       string
+	SecretID     string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string
+	// Endpoint overrides the regional endpoint, eg: for VPC endpoint.
+	Endpoint string
+	Client   *http.Client
+}
+
+// Get secret.
+func (a AWS) Get(ctx context.Context, name string) (string, error) {
+	endpoint := a.Endpoint
+	if endpoint == "" {
+		endpoint = "https://secretsmanager." + a.Region + ".amazonaws.com"
+	}
+
+	payload, err := json.Marshal(struct {
+		SecretID string `json:"SecretId"`
+	}{
+		SecretID: a.SecretID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimRight(endpoint, "/")+"/", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	a.sign(req, payload, time.Now().UTC())
+
+	var (
+		body struct {
+			SecretString string `json:"SecretString"`
+		}
+		values map[string]interface{}
+	)
+
+	if err := send(a.Client, req, &body); err != nil {
+		return "", err
+	}
+
+	if err := json.Unmarshal([]byte(body.SecretString), &values); err != nil {
+		return "", err
+	}
+
+	return lookup(values, name)
+}
+
+// sign request using AWS Signature Version 4.
+func (a AWS) sign(req *http.Request, payload []byte, now time.Time) {
+	var (
+		date        = now.Format("20060102")
+		timestamp   = now.Format("20060102T150405Z")
+		scope       = date + "/" + a.Region + "/secretsmanager/aws4_request"
+		payloadHash = sha256Hex(payload)
+	)
+
+	req.Header.Set("X-Amz-Date", timestamp)
+	if a.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", a.SessionToken)
+	}
+
+	var (
+		names   = []string{"content-type", "host", "x-amz-date", "x-amz-target"}
+		headers = map[string]string{
+			"content-type": req.Header.Get("Content-Type"),
+			"host":         req.URL.Host,
+			"x-amz-date":   timestamp,
+			"x-amz-target": req.Header.Get("X-Amz-Target"),
+		}
+	)
+
+	if a.SessionToken != "" {
+		names = append(names, "x-amz-security-token")
+		headers["x-amz-security-token"] = a.SessionToken
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	var (
+		signedHeaders    = strings.Join(names, ";")
+		canonicalRequest = strings.Join([]string{req.Method, "/", "", canonicalHeaders.String(), signedHeaders, payloadHash}, "\n")
+		stringToSign     = strings.Join([]string{"AWS4-HMAC-SHA256", timestamp, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+		key              = hmacSHA256([]byte("AWS4"+a.SecretKey), date)
+	)
+
+	key = hmacSHA256(key, a.Region)
+	key = hmacSHA256(key, "secretsmanager")
+	key = hmacSHA256(key, "aws4_request")
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+a.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+
+		", Signature="+hex.EncodeToString(hmacSHA256(key, stringToSign)))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
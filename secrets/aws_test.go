@@ -0,0 +1,69 @@
+package secrets
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAWS_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "secretsmanager.GetSecretValue", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "application/x-amz-json-1.1", r.Header.Get("Content-Type"))
+		assert.Equal(t, "session", r.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/"))
+
+		if string(body) != `{"SecretId":"todos"}` {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(`{"Name":"todos","SecretString":"{\"POSTGRESQL_PASSWORD\":\"secret\"}"}`))
+	}))
+	defer server.Close()
+
+	provider := AWS{
+		Region:       "ap-southeast-1",
+		SecretID:     "todos",
+		AccessKey:    "access",
+		SecretKey:    "key",
+		SessionToken: "session",
+		Endpoint:     server.URL,
+	}
+
+	value, err := provider.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", value)
+
+	_, err = provider.Get(context.TODO(), "ADMIN_TOKEN")
+	assert.Equal(t, ErrNotFound, err)
+
+	provider.SecretID = "missing"
+	_, err = provider.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+	assert.EqualError(t, err, "secrets: POST "+server.Listener.Addr().String()+" responded with 400 Bad Request")
+}
+
+func TestAWS_sign(t *testing.T) {
+	var (
+		provider = AWS{Region: "us-east-1", AccessKey: "AKIDEXAMPLE", SecretKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+		payload  = []byte(`{"SecretId":"todos"}`)
+		req, _   = http.NewRequest("POST", "https://secretsmanager.us-east-1.amazonaws.com/", strings.NewReader(string(payload)))
+		now      = time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	)
+
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	provider.sign(req, payload, now)
+
+	assert.Equal(t, "20261017T000000Z", req.Header.Get("X-Amz-Date"))
+	assert.Regexp(t, `^AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20261017/us-east-1/secretsmanager/aws4_request, SignedHeaders=content-type;host;x-amz-date;x-amz-target, Signature=[0-9a-f]{64}$`, req.Header.Get("Authorization"))
+}
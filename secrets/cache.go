@@ -0,0 +1,121 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type entry struct {
+	value   string
+	expires time.Time
+}
+
+// call is a fetch in flight, concurrent Get of the same secret waits for it instead of calling provider again.
+type call struct {
+	done  chan struct{}
+	value string
+	err   error
+	// stale is set when the secret is invalidated while it's being fetched, so the result isn't cached.
+	stale bool
+}
+
+// Cache secrets fetched from provider, secret is only fetched when it's first used and refetched once it's expired.
+// Cache itself is a Provider.
+type Cache struct {
+	provider Provider
+	ttl      time.Duration
+	lock     sync.Mutex
+	entries  map[string]entry
+	calls    map[string]*call
+}
+
+// Get secret from cache, or fetch it from provider when it's missing or expired.
+// Provider is called without holding the lock, and only once for concurrent Get of the same secret.
+// Expired secret is still returned when provider is unavailable, so a provider outage doesn't break running instance.
+func (c *Cache) Get(ctx context.Context, name string) (string, error) {
+	c.lock.Lock()
+
+	if cached, ok := c.entries[name]; ok && time.Now().Before(cached.expires) {
+		c.lock.Unlock()
+		return cached.value, nil
+	}
+
+	if pending, ok := c.calls[name]; ok {
+		c.lock.Unlock()
+
+		select {
+		case <-pending.done:
+			return pending.value, pending.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	pending := &call{done: make(chan struct{})}
+	c.calls[name] = pending
+	c.lock.Unlock()
+
+	value, err := c.provider.Get(ctx, name)
+
+	c.lock.Lock()
+	if c.calls[name] == pending {
+		delete(c.calls, name)
+	}
+
+	cached, ok := c.entries[name]
+	switch {
+	case err == nil && !pending.stale:
+		c.entries[name] = entry{value: value, expires: time.Now().Add(c.ttl)}
+	case err != nil && ok && err != ErrNotFound:
+		logger.Warn("using expired secret", zap.String("name", name), zap.Error(err))
+		value, err = cached.value, nil
+	}
+
+	pending.value, pending.err = value, err
+	c.lock.Unlock()
+	close(pending.done)
+
+	return value, err
+}
+
+// MustGet secret, missing secret is treated as empty like an unset environment variable.
+// It exits the program when provider fails, used by commands to read configuration on start.
+func (c *Cache) MustGet(ctx context.Context, name string) string {
+	value, err := c.Get(ctx, name)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		logger.Fatal("secrets error", zap.String("name", name), zap.Error(err))
+	}
+
+	return value
+}
+
+// Invalidate cached secret, so the next Get fetches the rotated secret from provider.
+// Secret that is being fetched is not cached, since it might be fetched before rotation.
+func (c *Cache) Invalidate(name string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cached, ok := c.entries[name]; ok {
+		cached.expires = time.Time{}
+		c.entries[name] = cached
+	}
+
+	if pending, ok := c.calls[name]; ok {
+		pending.stale = true
+		delete(c.calls, name)
+	}
+}
+
+// NewCache of provider.
+func NewCache(provider Provider, ttl time.Duration) *Cache {
+	return &Cache{
+		provider: provider,
+		ttl:      ttl,
+		entries:  make(map[string]entry),
+		calls:    make(map[string]*call),
+	}
+}
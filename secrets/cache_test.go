@@ -0,0 +1,171 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type provider struct {
+	values map[string]string
+	err    error
+	calls  int
+}
+
+func (p *provider) Get(ctx context.Context, name string) (string, error) {
+	p.calls++
+	if p.err != nil {
+		return "", p.err
+	}
+
+	value, ok := p.values[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
+
+func TestCache_Get(t *testing.T) {
+	var (
+		source = &provider{values: map[string]string{"POSTGRESQL_PASSWORD": "secret"}}
+		cache  = NewCache(source, time.Hour)
+	)
+
+	value, err := cache.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", value)
+
+	source.values["POSTGRESQL_PASSWORD"] = "rotated"
+	value, err = cache.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", value)
+	assert.Equal(t, 1, source.calls)
+
+	cache.Invalidate("POSTGRESQL_PASSWORD")
+	value, err = cache.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+	assert.Nil(t, err)
+	assert.Equal(t, "rotated", value)
+	assert.Equal(t, 2, source.calls)
+
+	_, err = cache.Get(context.TODO(), "ADMIN_TOKEN")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestCache_Get_expired(t *testing.T) {
+	var (
+		source = &provider{values: map[string]string{"POSTGRESQL_PASSWORD": "secret"}}
+		cache  = NewCache(source, time.Millisecond)
+	)
+
+	value, err := cache.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", value)
+
+	time.Sleep(5 * time.Millisecond)
+	source.values["POSTGRESQL_PASSWORD"] = "rotated"
+
+	value, err = cache.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+	assert.Nil(t, err)
+	assert.Equal(t, "rotated", value)
+
+	// provider outage keeps using expired secret.
+	time.Sleep(5 * time.Millisecond)
+	source.err = errors.New("unavailable")
+
+	value, err = cache.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+	assert.Nil(t, err)
+	assert.Equal(t, "rotated", value)
+
+	_, err = cache.Get(context.TODO(), "ADMIN_TOKEN")
+	assert.EqualError(t, err, "unavailable")
+}
+
+// blockingProvider blocks every Get until release is closed.
+type blockingProvider struct {
+	release chan struct{}
+	calls   int32
+}
+
+func (p *blockingProvider) Get(ctx context.Context, name string) (string, error) {
+	atomic.AddInt32(&p.calls, 1)
+	<-p.release
+	return name + "-value", nil
+}
+
+func TestCache_Get_singleFlight(t *testing.T) {
+	var (
+		source = &blockingProvider{release: make(chan struct{})}
+		cache  = NewCache(source, time.Hour)
+		wg     sync.WaitGroup
+		values = make([]string, 5)
+	)
+
+	cache.entries["ADMIN_TOKEN"] = entry{value: "token", expires: time.Now().Add(time.Hour)}
+
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], _ = cache.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+		}(i)
+	}
+
+	// cached secret is served while another secret is being fetched.
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&source.calls) == 1 }, time.Second, time.Millisecond)
+	value, err := cache.Get(context.TODO(), "ADMIN_TOKEN")
+	assert.Nil(t, err)
+	assert.Equal(t, "token", value)
+
+	// waiting Get returns when its context is done.
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	_, err = cache.Get(ctx, "POSTGRESQL_PASSWORD")
+	assert.Equal(t, context.Canceled, err)
+
+	close(source.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&source.calls))
+	for i := range values {
+		assert.Equal(t, "POSTGRESQL_PASSWORD-value", values[i])
+	}
+}
+
+func TestCache_Invalidate_fetching(t *testing.T) {
+	var (
+		source = &blockingProvider{release: make(chan struct{})}
+		cache  = NewCache(source, time.Hour)
+		done   = make(chan struct{})
+	)
+
+	go func() {
+		defer close(done)
+		cache.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+	}()
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&source.calls) == 1 }, time.Second, time.Millisecond)
+	cache.Invalidate("POSTGRESQL_PASSWORD")
+	close(source.release)
+	<-done
+
+	// value fetched before invalidation is not cached.
+	_, err := cache.Get(context.TODO(), "POSTGRESQL_PASSWORD")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&source.calls))
+}
+
+func TestCache_MustGet(t *testing.T) {
+	var (
+		source = &provider{values: map[string]string{"ADMIN_TOKEN": "token"}}
+		cache  = NewCache(source, time.Hour)
+	)
+
+	assert.Equal(t, "token", cache.MustGet(context.TODO(), "ADMIN_TOKEN"))
+	assert.Equal(t, "", cache.MustGet(context.TODO(), "SEARCH_KEY"))
+}
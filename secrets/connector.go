@@ -0,0 +1,97 @@
+package secrets
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net/url"
+	"os"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// Connector opens database connection using password stored as secret.
+// Password is resolved for every new connection, so connections opened after rotation use the new password,
+// combined with sql.DB.SetConnMaxLifetime existing connections are eventually reopened.
+type Connector struct {
+	secrets *Cache
+	name    string
+	driver  driver.Driver
+	dsn     func(password string) string
+}
+
+// Connect to database, when password is rejected the secret is refetched and connection is retried once if the password was rotated.
+// Other errors (eg: database is unreachable) are returned as is, so they don't cause extra calls to provider.
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	password, err := c.secrets.Get(ctx, c.name)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := c.connect(ctx, password)
+	if err == nil || !authenticationFailed(err) {
+		return conn, err
+	}
+
+	// cached password might be revoked before it's expired.
+	c.secrets.Invalidate(c.name)
+	if rotated, rerr := c.secrets.Get(ctx, c.name); rerr == nil && rotated != password {
+		logger.Info("reconnecting using rotated secret", zap.String("name", c.name))
+		return c.connect(ctx, rotated)
+	}
+
+	return nil, err
+}
+
+func (c *Connector) connect(ctx context.Context, password string) (driver.Conn, error) {
+	dsn := c.dsn(password)
+
+	if opener, ok := c.driver.(driver.DriverContext); ok {
+		connector, err := opener.OpenConnector(dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		return connector.Connect(ctx)
+	}
+
+	return c.driver.Open(dsn)
+}
+
+// NewPostgresConnector connects to postgres at host using POSTGRESQL_PASSWORD secret,
+// other connection parameters are read from POSTGRESQL_USERNAME, POSTGRESQL_PORT and POSTGRESQL_DATABASE environment variables.
+func NewPostgresConnector(secrets *Cache, host string) *Connector {
+	return NewConnector(secrets, "POSTGRESQL_PASSWORD", pq.Driver{}, func(password string) string {
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(os.Getenv("POSTGRESQL_USERNAME"), password),
+			Host:     host + ":" + os.Getenv("POSTGRESQL_PORT"),
+			Path:     os.Getenv("POSTGRESQL_DATABASE"),
+			RawQuery: "sslmode=disable",
+		}
+
+		return dsn.String()
+	})
+}
+
+// authenticationFailed reports whether postgres rejected the password (invalid_password, 28P01).
+func authenticationFailed(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "28P01"
+}
+
+// Driver returns underlying driver.
+func (c *Connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// NewConnector for sql.OpenDB, dsn builds data source name using the current password stored as secret name.
+func NewConnector(secrets *Cache, name string, driver driver.Driver, dsn func(password string) string) *Connector {
+	return &Connector{
+		secrets: secrets,
+		name:    name,
+		driver:  driver,
+		dsn:     dsn,
+	}
+}
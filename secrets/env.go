@@ -0,0 +1,19 @@
+package secrets
+
+import (
+	"context"
+	"os"
+)
+
+// Env provider reads secret from environment variable.
+type Env struct{}
+
+// Get secret.
+func (Env) Get(ctx context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	return value, nil
+}
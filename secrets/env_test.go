@@ -0,0 +1,19 @@
+package secrets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnv_Get(t *testing.T) {
+	t.Setenv("SECRETS_TEST_PASSWORD", "secret")
+
+	value, err := Env{}.Get(context.TODO(), "SECRETS_TEST_PASSWORD")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", value)
+
+	_, err = Env{}.Get(context.TODO(), "SECRETS_TEST_MISSING")
+	assert.Equal(t, ErrNotFound, err)
+}
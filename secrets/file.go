@@ -0,0 +1,30 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// File provider reads secret from a file named after the secret inside Dir, eg: docker or kubernetes mounted secrets.
+// Trailing newline is trimmed, rotated secret is picked up once the file is replaced.
+type File struct {
+	Dir string
+}
+
+// Get secret.
+func (f File) Get(ctx context.Context, name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", ErrNotFound
+	}
+
+	value, err := os.ReadFile(filepath.Join(f.Dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNotFound
+	}
+
+	return strings.TrimRight(string(value), "\r\n"), err
+}
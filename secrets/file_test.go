@@ -0,0 +1,38 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFile_Get(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		provider = File{Dir: dir}
+	)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "POSTGRESQL_PASSWORD"), []byte("secret\n"), 0600))
+
+	tests := []struct {
+		name  string
+		value string
+		err   error
+	}{
+		{name: "POSTGRESQL_PASSWORD", value: "secret"},
+		{name: "ADMIN_TOKEN", err: ErrNotFound},
+		{name: "../POSTGRESQL_PASSWORD", err: ErrNotFound},
+		{name: "..", err: ErrNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := provider.Get(context.TODO(), test.name)
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.value, value)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "secrets")))
+	// ErrNotFound returned when provider doesn't have the secret.
+	ErrNotFound = errors.New("secrets: not found")
+	// DefaultTTL of cached secret when SECRETS_TTL is not set.
+	DefaultTTL = 5 * time.Minute
+)
+
+// Provider fetches secret by name, name is the same as the environment variable it replaces (eg: POSTGRESQL_PASSWORD).
+type Provider interface {
+	Get(ctx context.Context, name string) (string, error)
+}
+
+// Open provider configured using environment variables, the provider is cached using SECRETS_TTL.
+// SECRETS_PROVIDER selects the driver: env (default), file, vault or aws.
+func Open() (*Cache, error) {
+	var (
+		provider Provider
+		ttl      = DefaultTTL
+	)
+
+	if str := os.Getenv("SECRETS_TTL"); str != "" {
+		var err error
+		if ttl, err = time.ParseDuration(str); err != nil {
+			return nil, fmt.Errorf("secrets: invalid SECRETS_TTL: %w", err)
+		}
+	}
+
+	switch driver := os.Getenv("SECRETS_PROVIDER"); driver {
+	case "", "env":
+		provider = Env{}
+	case "file":
+		provider = File{Dir: os.Getenv("SECRETS_DIR")}
+	case "vault":
+		provider = Vault{
+			Address: os.Getenv("VAULT_ADDR"),
+			Token:   os.Getenv("VAULT_TOKEN"),
+			Mount:   os.Getenv("VAULT_MOUNT"),
+			Path:    os.Getenv("VAULT_PATH"),
+		}
+	case "aws":
+		provider = AWS{
+			Region:       os.Getenv("AWS_REGION"),
+			SecretID:     os.Getenv("AWS_SECRET_ID"),
+			AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+		}
+	default:
+		return nil, fmt.Errorf("secrets: unknown provider %q", driver)
+	}
+
+	return NewCache(provider, ttl), nil
+}
+
+// MustOpen is like Open, but exits the program when provider is misconfigured, used by commands on start.
+func MustOpen() *Cache {
+	cache, err := Open()
+	if err != nil {
+		logger.Fatal("secrets error", zap.Error(err))
+	}
+
+	return cache
+}
@@ -0,0 +1,90 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// Timeout of request to remote provider when its client is not set.
+	Timeout = 10 * time.Second
+)
+
+// Vault provider reads secret from a HashiCorp Vault KV version 2 secret, each secret is a key of the KV secret at Path.
+type Vault struct {
+	Address string
+	Token   string
+	// Mount of the KV engine, default to "secret".
+	Mount  string
+	Path   string
+	Client *http.Client
+}
+
+// Get secret.
+func (v Vault) Get(ctx context.Context, name string) (string, error) {
+	mount := v.Mount
+	if mount == "" {
+		mount = "secret"
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimRight(v.Address, "/"), strings.Trim(mount, "/"), strings.Trim(v.Path, "/"))
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("X-Vault-Token", v.Token)
+
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+
+	if err := send(v.Client, req, &body); err != nil {
+		return "", err
+	}
+
+	return lookup(body.Data.Data, name)
+}
+
+// send request to remote provider and decode json response.
+func send(client *http.Client, req *http.Request, v interface{}) error {
+	if client == nil {
+		client = &http.Client{Timeout: Timeout}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode >= 300:
+		return fmt.Errorf("secrets: %s %s responded with %s", req.Method, req.URL.Host, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// lookup secret from key value secret, non string value is encoded as json.
+func lookup(values map[string]interface{}, name string) (string, error) {
+	value, ok := values[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	return string(encoded), err
+}
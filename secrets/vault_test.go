@@ -0,0 +1,76 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVault_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get("X-Vault-Token") != "token":
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path == "/v1/kv/data/todos":
+			w.Write([]byte(`{"data":{"data":{"POSTGRESQL_PASSWORD":"secret","PORT":3000}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		provider Vault
+		secret   string
+		value    string
+		err      string
+	}{
+		{
+			name:     "string",
+			provider: Vault{Address: server.URL, Token: "token", Mount: "kv", Path: "todos"},
+			secret:   "POSTGRESQL_PASSWORD",
+			value:    "secret",
+		},
+		{
+			name:     "number",
+			provider: Vault{Address: server.URL, Token: "token", Mount: "kv", Path: "todos"},
+			secret:   "PORT",
+			value:    "3000",
+		},
+		{
+			name:     "missing key",
+			provider: Vault{Address: server.URL, Token: "token", Mount: "kv", Path: "todos"},
+			secret:   "ADMIN_TOKEN",
+			err:      ErrNotFound.Error(),
+		},
+		{
+			name:     "missing path",
+			provider: Vault{Address: server.URL, Token: "token", Path: "todos"},
+			secret:   "POSTGRESQL_PASSWORD",
+			err:      ErrNotFound.Error(),
+		},
+		{
+			name:     "forbidden",
+			provider: Vault{Address: server.URL, Token: "invalid", Mount: "kv", Path: "todos"},
+			secret:   "POSTGRESQL_PASSWORD",
+			err:      "secrets: GET " + server.Listener.Addr().String() + " responded with 403 Forbidden",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := test.provider.Get(context.TODO(), test.secret)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.value, value)
+		})
+	}
+}
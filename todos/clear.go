@@ -3,13 +3,20 @@ package todos
 import (
 	"context"
 
+	"github.com/Fs02/go-todo-backend/events"
 	"github.com/go-rel/rel"
 )
 
 type clear struct {
 	repository rel.Repository
+	events     events.Service
 }
 
 func (c clear) Clear(ctx context.Context) {
 	c.repository.MustDeleteAny(ctx, rel.From("todos"))
+
+	// todos are already deleted, so only sync subscriber can fail it, similar to repository's Must functions.
+	if err := c.events.Publish(ctx, Cleared{}); err != nil {
+		panic(err)
+	}
 }
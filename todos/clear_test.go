@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Fs02/go-todo-backend/events/eventstest"
 	"github.com/go-rel/rel"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
@@ -13,14 +14,19 @@ func TestClear(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		service    = New(repository, nil)
+		events     = &eventstest.Service{}
+		service    = New(repository, events)
 	)
 
 	repository.ExpectDeleteAny(rel.From("todos")).Unsafe()
+	eventstest.Mock(events,
+		eventstest.MockPublish(Cleared{}, nil),
+	)
 
 	assert.NotPanics(t, func() {
 		service.Clear(ctx)
 	})
 
 	repository.AssertExpectations(t)
+	events.AssertExpectations(t)
 }
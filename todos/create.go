@@ -3,14 +3,14 @@ package todos
 import (
 	"context"
 
-	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/events"
 	"github.com/go-rel/rel"
 	"go.uber.org/zap"
 )
 
 type create struct {
 	repository rel.Repository
-	scores     scores.Service
+	events     events.Service
 }
 
 func (c create) Create(ctx context.Context, todo *Todo) error {
@@ -19,14 +19,18 @@ func (c create) Create(ctx context.Context, todo *Todo) error {
 		return err
 	}
 
-	// if completed, then earn a point.
-	if todo.Completed {
-		return c.repository.Transaction(ctx, func(ctx context.Context) error {
-			c.repository.MustInsert(ctx, todo)
-			return c.scores.Earn(ctx, "todo completed", 1)
-		})
-	}
+	return c.events.Transaction(ctx, func(ctx context.Context) error {
+		c.repository.MustInsert(ctx, todo)
+
+		if err := c.events.Publish(ctx, Created{Todo: *todo}); err != nil {
+			return err
+		}
+
+		// if completed, subscribers are notified as well (eg: to earn a point).
+		if todo.Completed {
+			return c.events.Publish(ctx, Completed{Todo: *todo})
+		}
 
-	c.repository.MustInsert(ctx, todo)
-	return nil
+		return nil
+	})
 }
@@ -2,9 +2,10 @@ package todos
 
 import (
 	"context"
+	"errors"
 	"testing"
 
-	"github.com/Fs02/go-todo-backend/scores/scorestest"
+	"github.com/Fs02/go-todo-backend/events/eventstest"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,52 +15,81 @@ func TestCreate(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
+		events     = &eventstest.Service{}
+		service    = New(repository, events)
 		todo       = Todo{Title: "Sleep"}
 	)
 
 	repository.ExpectInsert().For(&todo)
+	eventstest.Mock(events,
+		eventstest.MockTransaction(),
+		eventstest.MockPublish(mock.AnythingOfType("todos.Created"), nil),
+	)
 
 	assert.Nil(t, service.Create(ctx, &todo))
 	assert.NotEmpty(t, todo.ID)
 
 	repository.AssertExpectations(t)
-	scores.AssertExpectations(t)
+	events.AssertExpectations(t)
 }
 
 func TestCreate_completed(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
+		events     = &eventstest.Service{}
+		service    = New(repository, events)
 		todo       = Todo{Title: "Sleep", Completed: true}
 	)
 
-	repository.ExpectTransaction(func(repository *reltest.Repository) {
-		scores.On("Earn", mock.Anything, "todo completed", 1).Return(nil)
-		repository.ExpectInsert().For(&todo)
-	})
+	repository.ExpectInsert().For(&todo)
+	eventstest.Mock(events,
+		eventstest.MockTransaction(),
+		eventstest.MockPublish(mock.AnythingOfType("todos.Created"), nil),
+		eventstest.MockPublish(mock.AnythingOfType("todos.Completed"), nil),
+	)
 
 	assert.Nil(t, service.Create(ctx, &todo))
 	assert.NotEmpty(t, todo.ID)
 
 	repository.AssertExpectations(t)
-	scores.AssertExpectations(t)
+	events.AssertExpectations(t)
+}
+
+func TestCreate_publishError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		events     = &eventstest.Service{}
+		service    = New(repository, events)
+		todo       = Todo{Title: "Sleep", Completed: true}
+		err        = errors.New("earn error")
+	)
+
+	repository.ExpectInsert().For(&todo)
+	eventstest.Mock(events,
+		eventstest.MockTransaction(),
+		eventstest.MockPublish(mock.AnythingOfType("todos.Created"), nil),
+		eventstest.MockPublish(mock.AnythingOfType("todos.Completed"), err),
+	)
+
+	assert.Equal(t, err, service.Create(ctx, &todo))
+
+	repository.AssertExpectations(t)
+	events.AssertExpectations(t)
 }
 
 func TestCreate_validateError(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
+		events     = &eventstest.Service{}
+		service    = New(repository, events)
 		todo       = Todo{Title: ""}
 	)
 
 	assert.Equal(t, ErrTodoTitleBlank, service.Create(ctx, &todo))
 
 	repository.AssertExpectations(t)
-	scores.AssertExpectations(t)
+	events.AssertExpectations(t)
 }
@@ -3,13 +3,20 @@ package todos
 import (
 	"context"
 
+	"github.com/Fs02/go-todo-backend/events"
 	"github.com/go-rel/rel"
 )
 
 type delete struct {
 	repository rel.Repository
+	events     events.Service
 }
 
 func (d delete) Delete(ctx context.Context, todo *Todo) {
 	d.repository.MustDelete(ctx, todo)
+
+	// todo is already deleted, so only sync subscriber can fail it, similar to repository's Must functions.
+	if err := d.events.Publish(ctx, Deleted{Todo: *todo}); err != nil {
+		panic(err)
+	}
 }
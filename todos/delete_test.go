@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Fs02/go-todo-backend/events/eventstest"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
 )
@@ -12,15 +13,20 @@ func TestDelete(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		service    = New(repository, nil)
+		events     = &eventstest.Service{}
+		service    = New(repository, events)
 		todo       = Todo{ID: 1, Title: "Sleep"}
 	)
 
 	repository.ExpectDelete().ForType("todos.Todo")
+	eventstest.Mock(events,
+		eventstest.MockPublish(Deleted{Todo: todo}, nil),
+	)
 
 	assert.NotPanics(t, func() {
 		service.Delete(ctx, &todo)
 	})
 
 	repository.AssertExpectations(t)
+	events.AssertExpectations(t)
 }
@@ -0,0 +1,74 @@
+package todos
+
+const (
+	// EventCreated published after a todo is created.
+	EventCreated = "todo.created"
+	// EventCompleted published after a todo is created as completed or marked as completed.
+	EventCompleted = "todo.completed"
+	// EventUncompleted published after a completed todo is marked as not completed.
+	EventUncompleted = "todo.uncompleted"
+	// EventUpdated published after every update, after EventCompleted or EventUncompleted if it's published.
+	EventUpdated = "todo.updated"
+	// EventDeleted published after a todo is deleted.
+	EventDeleted = "todo.deleted"
+	// EventCleared published after every todo is deleted.
+	EventCleared = "todo.cleared"
+)
+
+// Created event.
+type Created struct {
+	Todo Todo
+}
+
+// EventName of the event.
+func (Created) EventName() string {
+	return EventCreated
+}
+
+// Completed event.
+type Completed struct {
+	Todo Todo
+}
+
+// EventName of the event.
+func (Completed) EventName() string {
+	return EventCompleted
+}
+
+// Uncompleted event.
+type Uncompleted struct {
+	Todo Todo
+}
+
+// EventName of the event.
+func (Uncompleted) EventName() string {
+	return EventUncompleted
+}
+
+// Updated event.
+type Updated struct {
+	Todo Todo
+}
+
+// EventName of the event.
+func (Updated) EventName() string {
+	return EventUpdated
+}
+
+// Deleted event.
+type Deleted struct {
+	Todo Todo
+}
+
+// EventName of the event.
+func (Deleted) EventName() string {
+	return EventDeleted
+}
+
+// Cleared event.
+type Cleared struct{}
+
+// EventName of the event.
+func (Cleared) EventName() string {
+	return EventCleared
+}
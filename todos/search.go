@@ -2,14 +2,39 @@ package todos
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	// Sorts allowed when searching todos, prefix it with "-" to sort descending.
+	Sorts = []string{"order", "title", "created_at", "updated_at"}
+	// ErrFilterSortInvalid validation error.
+	ErrFilterSortInvalid = errors.New("Sort is invalid")
 )
 
 // Filter for search.
 type Filter struct {
 	Keyword   string
 	Completed *bool
+	Sort      string
+	// Limit of returned todos, all matching todos are returned when zero.
+	Limit  int
+	Offset int
+}
+
+// Validate filter.
+func (f Filter) Validate() error {
+	var err error
+	switch {
+	case f.Sort != "" && !contains(Sorts, strings.TrimPrefix(f.Sort, "-")):
+		err = ErrFilterSortInvalid
+	}
+
+	return err
 }
 
 type search struct {
@@ -17,10 +42,27 @@ type search struct {
 }
 
 func (s search) Search(ctx context.Context, todos *[]Todo, filter Filter) error {
+	if err := filter.Validate(); err != nil {
+		logger.Warn("validation error", zap.Error(err))
+		return err
+	}
+
 	var (
-		query = rel.Select().SortAsc("order")
+		query = rel.Select()
 	)
 
+	switch {
+	case filter.Sort == "":
+		query = query.SortAsc("order")
+	case strings.HasPrefix(filter.Sort, "-"):
+		query = query.SortDesc(strings.TrimPrefix(filter.Sort, "-"))
+	default:
+		query = query.SortAsc(filter.Sort)
+	}
+
+	// sorted columns aren't unique, id breaks the tie so pagination doesn't skip or repeat todos.
+	query = query.SortAsc("id")
+
 	if filter.Keyword != "" {
 		query = query.Where(rel.Like("title", "%"+filter.Keyword+"%"))
 	}
@@ -29,6 +71,24 @@ func (s search) Search(ctx context.Context, todos *[]Todo, filter Filter) error
 		query = query.Where(rel.Eq("completed", *filter.Completed))
 	}
 
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
 	s.repository.MustFindAll(ctx, todos, query)
 	return nil
 }
+
+func contains(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -21,7 +21,7 @@ func TestSearch(t *testing.T) {
 	)
 
 	repository.ExpectFindAll(
-		rel.Select().SortAsc("order").Where(rel.Like("title", "%Sleep%").AndEq("completed", false)),
+		rel.Select().SortAsc("order").SortAsc("id").Where(rel.Like("title", "%Sleep%").AndEq("completed", false)),
 	).Result(result)
 
 	assert.NotPanics(t, func() {
@@ -31,3 +31,31 @@ func TestSearch(t *testing.T) {
 
 	repository.AssertExpectations(t)
 }
+
+func TestSearch_sort(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, nil)
+		todos      []Todo
+		result     = []Todo{{ID: 1, Title: "Sleep"}}
+	)
+
+	repository.ExpectFindAll(rel.Select().SortDesc("created_at").SortAsc("id")).Result(result)
+
+	assert.Nil(t, service.Search(ctx, &todos, Filter{Sort: "-created_at"}))
+	assert.Equal(t, result, todos)
+	repository.AssertExpectations(t)
+}
+
+func TestSearch_validateError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository, nil)
+		todos      []Todo
+	)
+
+	assert.Equal(t, ErrFilterSortInvalid, service.Search(ctx, &todos, Filter{Sort: "-completed"}))
+	repository.AssertExpectations(t)
+}
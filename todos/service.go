@@ -3,7 +3,7 @@ package todos
 import (
 	"context"
 
-	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/events"
 	"github.com/go-rel/rel"
 	"go.uber.org/zap"
 )
@@ -37,12 +37,13 @@ type service struct {
 var _ Service = (*service)(nil)
 
 // New Todos service.
-func New(repository rel.Repository, scores scores.Service) Service {
+// Changes to todos are published as events, see events.go.
+func New(repository rel.Repository, events events.Service) Service {
 	return service{
 		search: search{repository: repository},
-		create: create{repository: repository, scores: scores},
-		update: update{repository: repository, scores: scores},
-		delete: delete{repository: repository},
-		clear:  clear{repository: repository},
+		create: create{repository: repository, events: events},
+		update: update{repository: repository, events: events},
+		delete: delete{repository: repository, events: events},
+		clear:  clear{repository: repository, events: events},
 	}
 }
@@ -13,6 +13,10 @@ var (
 	TodoURLPrefix = os.Getenv("URL") + "todos/"
 	// ErrTodoTitleBlank validation error.
 	ErrTodoTitleBlank = errors.New("Title can't be blank")
+	// Fields of encoded todo that can be selected.
+	Fields = []string{"id", "title", "order", "completed", "url", "created_at", "updated_at"}
+	// ErrTodoFieldInvalid validation error.
+	ErrTodoFieldInvalid = errors.New("Field is invalid")
 )
 
 // Todo respresent a record stored in todos table.
@@ -36,6 +40,17 @@ func (t Todo) Validate() error {
 	return err
 }
 
+// ValidateFields ensures every selected field is part of encoded todo.
+func ValidateFields(fields []string) error {
+	for i := range fields {
+		if !contains(Fields, fields[i]) {
+			return ErrTodoFieldInvalid
+		}
+	}
+
+	return nil
+}
+
 // MarshalJSON implement custom marshaller to marshal url.
 func (t Todo) MarshalJSON() ([]byte, error) {
 	type Alias Todo
@@ -24,6 +24,12 @@ func TestTodo_Validate(t *testing.T) {
 	})
 }
 
+func TestValidateFields(t *testing.T) {
+	assert.Nil(t, ValidateFields(nil))
+	assert.Nil(t, ValidateFields([]string{"title", "url"}))
+	assert.Equal(t, ErrTodoFieldInvalid, ValidateFields([]string{"title", "secret"}))
+}
+
 func TestTodo_MarshalJSON(t *testing.T) {
 	var (
 		todo = Todo{
@@ -3,14 +3,14 @@ package todos
 import (
 	"context"
 
-	"github.com/Fs02/go-todo-backend/scores"
+	"github.com/Fs02/go-todo-backend/events"
 	"github.com/go-rel/rel"
 	"go.uber.org/zap"
 )
 
 type update struct {
 	repository rel.Repository
-	scores     scores.Service
+	events     events.Service
 }
 
 func (u update) Update(ctx context.Context, todo *Todo, changes rel.Changeset) error {
@@ -19,19 +19,24 @@ func (u update) Update(ctx context.Context, todo *Todo, changes rel.Changeset) e
 		return err
 	}
 
-	// update score if completed is changed.
+	// notify subscribers if completed is changed.
 	if changes.FieldChanged("completed") {
-		return u.repository.Transaction(ctx, func(ctx context.Context) error {
+		return u.events.Transaction(ctx, func(ctx context.Context) error {
 			u.repository.MustUpdate(ctx, todo, changes)
 
+			var event events.Event = Uncompleted{Todo: *todo}
 			if todo.Completed {
-				return u.scores.Earn(ctx, "todo completed", 1)
+				event = Completed{Todo: *todo}
 			}
 
-			return u.scores.Earn(ctx, "todo uncompleted", -2)
+			if err := u.events.Publish(ctx, event); err != nil {
+				return err
+			}
+
+			return u.events.Publish(ctx, Updated{Todo: *todo})
 		})
 	}
 
 	u.repository.MustUpdate(ctx, todo, changes)
-	return nil
+	return u.events.Publish(ctx, Updated{Todo: *todo})
 }
@@ -4,7 +4,7 @@ import (
 	"context"
 	"testing"
 
-	"github.com/Fs02/go-todo-backend/scores/scorestest"
+	"github.com/Fs02/go-todo-backend/events/eventstest"
 	"github.com/go-rel/rel"
 	"github.com/go-rel/reltest"
 	"github.com/stretchr/testify/assert"
@@ -15,8 +15,8 @@ func TestUpdate(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
+		events     = &eventstest.Service{}
+		service    = New(repository, events)
 		todo       = Todo{ID: 1, Title: "Sleep"}
 		changes    = rel.NewChangeset(&todo)
 	)
@@ -24,68 +24,75 @@ func TestUpdate(t *testing.T) {
 	todo.Title = "Wake up"
 
 	repository.ExpectUpdate(changes).ForType("todos.Todo")
+	eventstest.Mock(events,
+		eventstest.MockPublish(mock.AnythingOfType("todos.Updated"), nil),
+	)
 
 	assert.Nil(t, service.Update(ctx, &todo, changes))
 	assert.NotEmpty(t, todo.ID)
 
 	repository.AssertExpectations(t)
-	scores.AssertExpectations(t)
+	events.AssertExpectations(t)
 }
 
 func TestUpdate_completed(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
+		events     = &eventstest.Service{}
+		service    = New(repository, events)
 		todo       = Todo{ID: 1, Title: "Sleep"}
 		changes    = rel.NewChangeset(&todo)
 	)
 
 	todo.Completed = true
 
-	repository.ExpectTransaction(func(repository *reltest.Repository) {
-		scores.On("Earn", mock.Anything, "todo completed", 1).Return(nil)
-		repository.ExpectUpdate(changes).ForType("todos.Todo")
-	})
+	repository.ExpectUpdate(changes).ForType("todos.Todo")
+	eventstest.Mock(events,
+		eventstest.MockTransaction(),
+		eventstest.MockPublish(mock.AnythingOfType("todos.Completed"), nil),
+		eventstest.MockPublish(mock.AnythingOfType("todos.Updated"), nil),
+	)
 
 	assert.Nil(t, service.Update(ctx, &todo, changes))
 	assert.NotEmpty(t, todo.ID)
 
 	repository.AssertExpectations(t)
-	scores.AssertExpectations(t)
+	events.AssertExpectations(t)
 }
 
 func TestUpdate_uncompleted(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
+		events     = &eventstest.Service{}
+		service    = New(repository, events)
 		todo       = Todo{ID: 1, Title: "Sleep", Completed: true}
 		changes    = rel.NewChangeset(&todo)
 	)
 
 	todo.Completed = false
 
-	repository.ExpectTransaction(func(repository *reltest.Repository) {
-		scores.On("Earn", mock.Anything, "todo uncompleted", -2).Return(nil)
-		repository.ExpectUpdate(changes).ForType("todos.Todo")
-	})
+	repository.ExpectUpdate(changes).ForType("todos.Todo")
+	eventstest.Mock(events,
+		eventstest.MockTransaction(),
+		eventstest.MockPublish(mock.AnythingOfType("todos.Uncompleted"), nil),
+		eventstest.MockPublish(mock.AnythingOfType("todos.Updated"), nil),
+	)
 
 	assert.Nil(t, service.Update(ctx, &todo, changes))
 	assert.NotEmpty(t, todo.ID)
 
 	repository.AssertExpectations(t)
-	scores.AssertExpectations(t)
+	events.AssertExpectations(t)
 }
 
 func TestUpdate_validateError(t *testing.T) {
 	var (
 		ctx        = context.TODO()
 		repository = reltest.New()
-		scores     = &scorestest.Service{}
-		service    = New(repository, scores)
+		events     = &eventstest.Service{}
+		service    = New(repository, events)
 		todo       = Todo{ID: 1, Title: "Sleep"}
 		changes    = rel.NewChangeset(&todo)
 	)
@@ -95,5 +102,5 @@ func TestUpdate_validateError(t *testing.T) {
 	assert.Equal(t, ErrTodoTitleBlank, service.Update(ctx, &todo, changes))
 
 	repository.AssertExpectations(t)
-	scores.AssertExpectations(t)
+	events.AssertExpectations(t)
 }
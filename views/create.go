@@ -0,0 +1,22 @@
+package views
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+type create struct {
+	repository rel.Repository
+}
+
+func (c create) Create(ctx context.Context, view *View) error {
+	if err := view.Validate(); err != nil {
+		logger.Warn("validation error", zap.Error(err))
+		return err
+	}
+
+	c.repository.MustInsert(ctx, view)
+	return nil
+}
@@ -0,0 +1,37 @@
+package views
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+		view       = View{Name: "Done", Sort: "-updated_at"}
+	)
+
+	repository.ExpectInsert().For(&view)
+
+	assert.Nil(t, service.Create(ctx, &view))
+	assert.NotEmpty(t, view.ID)
+
+	repository.AssertExpectations(t)
+}
+
+func TestCreate_validateError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+		view       = View{Name: ""}
+	)
+
+	assert.Equal(t, ErrViewNameBlank, service.Create(ctx, &view))
+	repository.AssertExpectations(t)
+}
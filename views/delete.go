@@ -0,0 +1,15 @@
+package views
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+)
+
+type delete struct {
+	repository rel.Repository
+}
+
+func (d delete) Delete(ctx context.Context, view *View) {
+	d.repository.MustDelete(ctx, view)
+}
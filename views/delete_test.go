@@ -0,0 +1,26 @@
+package views
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelete(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+		view       = View{ID: 1, Name: "Done"}
+	)
+
+	repository.ExpectDelete().ForType("views.View")
+
+	assert.NotPanics(t, func() {
+		service.Delete(ctx, &view)
+	})
+
+	repository.AssertExpectations(t)
+}
@@ -0,0 +1,41 @@
+package views
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+var (
+	logger, _ = zap.NewProduction(zap.Fields(zap.String("type", "views")))
+)
+
+//go:generate mockery --name=Service --case=underscore --output viewstest --outpkg viewstest
+
+// Service instance for view's domain.
+// Any operation done to any of object within this domain should use this service.
+type Service interface {
+	Create(ctx context.Context, view *View) error
+	Update(ctx context.Context, view *View, changes rel.Changeset) error
+	Delete(ctx context.Context, view *View)
+}
+
+// beside embeding the struct, you can also declare the function directly on this struct.
+// the advantage of embedding the struct is it allows spreading the implementation across multiple files.
+type service struct {
+	create
+	update
+	delete
+}
+
+var _ Service = (*service)(nil)
+
+// New Views service.
+func New(repository rel.Repository) Service {
+	return service{
+		create: create{repository: repository},
+		update: update{repository: repository},
+		delete: delete{repository: repository},
+	}
+}
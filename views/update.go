@@ -0,0 +1,22 @@
+package views
+
+import (
+	"context"
+
+	"github.com/go-rel/rel"
+	"go.uber.org/zap"
+)
+
+type update struct {
+	repository rel.Repository
+}
+
+func (u update) Update(ctx context.Context, view *View, changes rel.Changeset) error {
+	if err := view.Validate(); err != nil {
+		logger.Warn("validation error", zap.Error(err))
+		return err
+	}
+
+	u.repository.MustUpdate(ctx, view, changes)
+	return nil
+}
@@ -0,0 +1,43 @@
+package views
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/go-rel/rel"
+	"github.com/go-rel/reltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+		view       = View{ID: 1, Name: "Done"}
+		changes    = rel.NewChangeset(&view)
+	)
+
+	view.Fields = "title"
+
+	repository.ExpectUpdate(changes).ForType("views.View")
+
+	assert.Nil(t, service.Update(ctx, &view, changes))
+	repository.AssertExpectations(t)
+}
+
+func TestUpdate_validateError(t *testing.T) {
+	var (
+		ctx        = context.TODO()
+		repository = reltest.New()
+		service    = New(repository)
+		view       = View{ID: 1, Name: "Done"}
+		changes    = rel.NewChangeset(&view)
+	)
+
+	view.Fields = "secret"
+
+	assert.Equal(t, todos.ErrTodoFieldInvalid, service.Update(ctx, &view, changes))
+	repository.AssertExpectations(t)
+}
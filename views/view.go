@@ -0,0 +1,66 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Fs02/go-todo-backend/todos"
+)
+
+var (
+	// ErrViewNameBlank validation error.
+	ErrViewNameBlank = errors.New("Name can't be blank")
+)
+
+// View respresent a saved search stored in views table.
+// Filter, sort and fields use the same format as query parameters of GET /todos.
+type View struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Keyword   string    `json:"keyword"`
+	Completed *bool     `json:"completed"`
+	Sort      string    `json:"sort"`
+	Fields    string    `json:"fields"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate view.
+func (v View) Validate() error {
+	var err error
+	switch {
+	case len(v.Name) == 0:
+		err = ErrViewNameBlank
+	default:
+		if err = v.Filter().Validate(); err == nil {
+			err = todos.ValidateFields(v.FieldList())
+		}
+	}
+
+	return err
+}
+
+// Filter used to search todos.
+func (v View) Filter() todos.Filter {
+	return todos.Filter{
+		Keyword:   v.Keyword,
+		Completed: v.Completed,
+		Sort:      v.Sort,
+	}
+}
+
+// FieldList returns selected fields, empty means every field is selected.
+func (v View) FieldList() []string {
+	var (
+		fields []string
+	)
+
+	for _, field := range strings.Split(v.Fields, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,56 @@
+package views
+
+import (
+	"testing"
+
+	"github.com/Fs02/go-todo-backend/todos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestView_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		view View
+		err  error
+	}{
+		{
+			name: "name is blank",
+			view: View{},
+			err:  ErrViewNameBlank,
+		},
+		{
+			name: "sort is invalid",
+			view: View{Name: "Done", Sort: "-secret"},
+			err:  todos.ErrFilterSortInvalid,
+		},
+		{
+			name: "field is invalid",
+			view: View{Name: "Done", Fields: "title,secret"},
+			err:  todos.ErrTodoFieldInvalid,
+		},
+		{
+			name: "valid",
+			view: View{Name: "Done", Sort: "-updated_at", Fields: "title, completed"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.err, test.view.Validate())
+		})
+	}
+}
+
+func TestView_Filter(t *testing.T) {
+	var (
+		completed = true
+		view      = View{Keyword: "Wake", Completed: &completed, Sort: "title"}
+	)
+
+	assert.Equal(t, todos.Filter{Keyword: "Wake", Completed: &completed, Sort: "title"}, view.Filter())
+}
+
+func TestView_FieldList(t *testing.T) {
+	assert.Nil(t, View{}.FieldList())
+	assert.Equal(t, []string{"title", "completed"}, View{Fields: " title,,completed "}.FieldList())
+}
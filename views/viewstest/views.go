@@ -0,0 +1,54 @@
+package viewstest
+
+import (
+	context "context"
+
+	views "github.com/Fs02/go-todo-backend/views"
+	rel "github.com/go-rel/rel"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFunc function.
+type MockFunc func(service *Service)
+
+// Mock apply mock view functions.
+func Mock(service *Service, funcs ...MockFunc) {
+	for i := range funcs {
+		if funcs[i] != nil {
+			funcs[i](service)
+		}
+	}
+}
+
+// MockCreate util.
+func MockCreate(result views.View, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Create", mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, out *views.View) error {
+				*out = result
+				return err
+			})
+	}
+}
+
+// MockUpdate util.
+func MockUpdate(result views.View, err error) MockFunc {
+	return func(service *Service) {
+		service.On("Update", mock.Anything, mock.Anything, mock.Anything).
+			Return(func(ctx context.Context, out *views.View, changeset rel.Changeset) error {
+				if result.ID != out.ID {
+					panic("inconsistent id")
+				}
+
+				*out = result
+				return err
+			})
+	}
+}
+
+// MockDelete util.
+func MockDelete() MockFunc {
+	return func(service *Service) {
+		service.On("Delete", mock.Anything, mock.Anything)
+	}
+}